@@ -8,11 +8,26 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/apisurface"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/chainlen"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/clones"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/cyclo"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/embeddepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/errpaths"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/fanout"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/filesize"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/generics"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/importdepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/mutation"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/nestdepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/outliers"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/params"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/risk"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/sideeffects"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/typeexpr"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/varspan"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/checker"
 	"golang.org/x/tools/go/analysis/unitchecker"
@@ -30,6 +45,21 @@ func main() {
 		cyclo.Analyzer,
 		params.Analyzer,
 		fanout.Analyzer,
+		typeexpr.Analyzer,
+		generics.Analyzer,
+		embeddepth.Analyzer,
+		coupling.Analyzer,
+		importdepth.Analyzer,
+		apisurface.Analyzer,
+		filesize.Analyzer,
+		chainlen.Analyzer,
+		mutation.Analyzer,
+		varspan.Analyzer,
+		errpaths.Analyzer,
+		sideeffects.Analyzer,
+		clones.Analyzer,
+		risk.Analyzer,
+		outliers.Analyzer,
 	}
 
 	// When invoked by "go vet -vettool", delegate to unitchecker
@@ -52,6 +82,14 @@ func main() {
 	// Register a global -exclude flag (shared across all analyzers).
 	flag.StringVar(&common.ExcludePatterns, "exclude", "",
 		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+	flag.BoolVar(&common.FuncLits, "funclits", false,
+		"also measure each function literal as its own unit (named like Outer.func1)")
+	flag.BoolVar(&common.DetachFuncLits, "funclits-detach", false,
+		"with -funclits, leave function literal bodies out of the enclosing function's counts")
+	flag.BoolVar(&common.Iterators, "iterators", false,
+		"do not charge iterator literals (func(yield func(T) bool)) a nesting level or their if !yield(...) { return } exits")
+	flag.Var(&common.Guards, "guards",
+		"comma-separated guard patterns exempt like error guards: commaok, nilcheck, logged, loop, panic")
 	flag.Var(&warningsMode, "warnings",
 		"warning handling: default (print, exit 0), none (suppress), error (print, exit 1)")
 
@@ -59,8 +97,9 @@ func main() {
 	// Also register hyphen-separated aliases (e.g., cyclo-warn) for convenience.
 	for _, a := range analyzers {
 		a.Flags.VisitAll(func(f *flag.Flag) {
-			if f.Name == "exclude" {
-				return // covered by the global -exclude flag
+			switch f.Name {
+			case "exclude", "funclits", "funclits-detach", "iterators", "guards":
+				return // covered by the global flags of the same name
 			}
 			name := a.Name + "." + f.Name
 			flag.Var(f.Value, name, f.Usage)
@@ -88,6 +127,21 @@ Analyzers:
   cyclo       reports functions with high cyclomatic complexity
   params      reports functions with too many parameters
   fanout      reports functions with high fan-out
+  typeexpr    reports deeply composed or large type expressions
+  generics    reports generics with many type parameters or complex constraints
+  embeddepth  reports types with deep struct or interface embedding
+  coupling    reports packages with high coupling or far from the main sequence
+  importdepth reports packages with deep or wide module-internal import stacks
+  apisurface  reports packages with a large exported API
+  filesize    reports files with too many lines, declarations or functions
+  chainlen    reports long selector and method call chains
+  mutation    reports functions that reassign or mutate many local variables
+  varspan     reports functions whose local variables live far from their uses
+  errpaths    reports functions with many distinct error exits
+  sideeffects reports functions with many side-effecting calls and global writes
+  clones      reports duplicated blocks of code
+  risk        reports functions whose combined complexity is high
+  outliers    reports functions whose metrics are outliers within their package or module
 
 Flags are namespaced by analyzer (dot or hyphen separator). The warn/fail
 values are inclusive lower bounds (a value at or above the threshold triggers
@@ -96,11 +150,43 @@ the zone). Defaults shown:
   -cyclo.warn=10     -cyclo.fail=15
   -params.warn=5     -params.fail=7
   -fanout.warn=7     -fanout.fail=10
+  -typeexpr.warn=5   -typeexpr.fail=7   -typeexpr.size-warn=10  -typeexpr.size-fail=15
+  -generics.warn=3   -generics.fail=5   -generics.constraint-warn=5  -generics.constraint-fail=8
+  -embeddepth.warn=3 -embeddepth.fail=5 -embeddepth.ambiguity-warn=2 -embeddepth.ambiguity-fail=4
+  -coupling.warn=70  -coupling.fail=90  -coupling.efferent-warn=10  -coupling.efferent-fail=15
+  -importdepth.warn=5 -importdepth.fail=8 -importdepth.transitive-warn=25 -importdepth.transitive-fail=50
+  -apisurface.warn=60 -apisurface.fail=100 -apisurface.params-warn=120 -apisurface.params-fail=200
+  -filesize.warn=750  -filesize.fail=1500 -filesize.decls-warn=40 -filesize.decls-fail=80 -filesize.funcs-warn=25 -filesize.funcs-fail=50
+  -chainlen.warn=4   -chainlen.fail=6
+  -mutation.warn=10  -mutation.fail=20
+  -varspan.warn=40   -varspan.fail=80   -varspan.avg-warn=15 -varspan.avg-fail=25 -varspan.stmts-warn=25 -varspan.stmts-fail=50
+  -errpaths.warn=8   -errpaths.fail=15
+  -sideeffects.warn=5 -sideeffects.fail=10
+  -clones.warn=40    -clones.fail=120
+  -risk.warn=70      -risk.fail=90      -risk.nestdepth-weight=1 -risk.cyclo-weight=1 -risk.params-weight=1 -risk.fanout-weight=1
+  -outliers.warn=95  -outliers.fail=99  -outliers.sigma-warn=2 -outliers.sigma-fail=3
 
 Hyphen-separated aliases also work:
   -cyclo-warn=10     -cyclo-fail=15
 
   -exclude="*_gen.go,mock_*.go"  skip files matching glob patterns
+  -funclits                      also report function literals as units (Outer.func1) in nestdepth/cyclo/params/fanout
+  -funclits-detach               with -funclits, leave literal bodies out of the enclosing function
+  -iterators                     no nestdepth level or cyclo point for iterator literals' yield exits
+  -guards="commaok,loop"         also exempt guard patterns: commaok, nilcheck, logged, loop, panic
+  -params.ctx-names=any          exempt context.Context parameters of any name, not only ctx
+  -params.exempt="testing,http"  also exempt *testing.T, (w, r) of handlers or listed qualified types
+  -params.weighted               weigh bool flags, func and any parameters and transposable neighbors extra
+  -chainlen.exempt="*Builder"    do not count selections on matching receiver types
+  -sideeffects.extra-packages=PKGS  effectful packages added to the default I/O list
+  -sideeffects.packages="os,net"  effectful packages (replaces the default I/O list)
+  -outliers.enable               report outliers (off by default)
+  -outliers.errors               report outliers past the fail thresholds as failures, not warnings
+  -outliers.mode=percentile      outlier test: percentile, stddev or both
+  -outliers.scope=package        compare functions within each package, or the whole module
+  -outliers.min-funcs=10         smallest population outliers are reported in
+  -outliers.floor=50             ignore values below this percent of a metric's warn threshold
+  -outliers.replace              report outliers instead of absolute nestdepth/cyclo/params/fanout
 
   -warnings=default  print warnings, exit 0 when only warnings are present
   -warnings=none     suppress warning output, exit 0 when only warnings are present
@@ -113,9 +199,10 @@ Exit codes:
 		os.Exit(1)
 	}
 
-	// Load packages.
+	// Load packages. NeedModule tells the module-scoped analyzers (coupling,
+	// importdepth) which imports belong to the module under analysis.
 	cfg := &packages.Config{
-		Mode: packages.LoadAllSyntax,
+		Mode: packages.LoadAllSyntax | packages.NeedModule,
 	}
 	pkgs, err := packages.Load(cfg, args...)
 	if err != nil {
@@ -125,12 +212,17 @@ Exit codes:
 		os.Exit(1)
 	}
 
-	// Run analysis.
+	// Run analysis. Module-scope outliers and clones are reported over the
+	// finished graph below.
+	outliers.ModuleReport = true
+	clones.ModuleReport = true
 	graph, err := checker.Analyze(analyzers, pkgs, nil)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	reportModule(graph)
+
 	failed, err := printDiagnostics(os.Stderr, graph, warningsMode)
 	if err != nil {
 		log.Fatal(err)
@@ -139,3 +231,16 @@ Exit codes:
 		os.Exit(1)
 	}
 }
+
+// reportModule reports the metrics that need every package's results, over
+// the finished graph.
+func reportModule(graph *checker.Graph) {
+	// Package coupling metrics need every package's facts before they can be
+	// reported.
+	coupling.ReportModule(graph)
+	// Module-scope outliers and -outliers.replace need every package too.
+	outliers.ReportModule(graph)
+	// Clones are matched across sibling packages that do not import each
+	// other.
+	clones.ReportModule(graph)
+}
@@ -8,11 +8,26 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/apisurface"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/chainlen"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/clones"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/cyclo"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/embeddepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/errpaths"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/fanout"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/filesize"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/generics"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/importdepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/mutation"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/nestdepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/outliers"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/params"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/risk"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/sideeffects"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/typeexpr"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/varspan"
 	"golang.org/x/tools/go/analysis"
 )
 
@@ -22,6 +37,21 @@ func TestVetRedZoneDefaults(t *testing.T) {
 		cyclo.Analyzer,
 		params.Analyzer,
 		fanout.Analyzer,
+		typeexpr.Analyzer,
+		generics.Analyzer,
+		embeddepth.Analyzer,
+		coupling.Analyzer,
+		importdepth.Analyzer,
+		apisurface.Analyzer,
+		filesize.Analyzer,
+		chainlen.Analyzer,
+		mutation.Analyzer,
+		varspan.Analyzer,
+		errpaths.Analyzer,
+		sideeffects.Analyzer,
+		clones.Analyzer,
+		risk.Analyzer,
+		outliers.Analyzer,
 	}
 
 	saved := make(map[*analysis.Analyzer]string, len(analyzers))
@@ -142,3 +172,50 @@ func TestWarningsModeInvalidCLI(t *testing.T) {
 		t.Fatal("expected invalid -warnings value to fail")
 	}
 }
+
+func TestThirdPartyImportsCLI(t *testing.T) {
+	bin := buildBinary(t)
+	dir := filepath.Join("..", "..", "pkg", "analyzer", "importdepth", "testdata", "thirdparty")
+	out := runFixtureModule(t, bin, dir, "-importdepth.warn=1", "-importdepth.fail=3")
+
+	if want := "package app has an import depth of 1 via app -> core "; !strings.Contains(out, want) {
+		t.Errorf("stderr missing %q:\n%s", want, out)
+	}
+	if strings.Contains(out, "example.org/dep") {
+		t.Errorf("third-party packages counted as module packages:\n%s", out)
+	}
+}
+
+func TestThirdPartyCouplingCLI(t *testing.T) {
+	bin := buildBinary(t)
+	dir := filepath.Join("..", "..", "pkg", "analyzer", "coupling", "testdata", "thirdparty")
+	out := runFixtureModule(t, bin, dir, "-coupling.efferent-warn=1", "-coupling.efferent-fail=2")
+
+	for _, want := range []string{
+		"package app has efferent coupling of 1 ",
+		"package model has distance from the main sequence of 1.00 (warn: >=0.70, fail: >=0.90) [error] (Ca=1, Ce=0, I=0.00, A=0.00;",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stderr missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// runFixtureModule runs bin on every package of the fixture module in dir,
+// whose third-party dependency example.org/dep is replaced by a local copy,
+// and returns its diagnostics. The exit code is not checked.
+func runFixtureModule(t *testing.T, bin, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command(bin, append(args, "./...")...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOPROXY=off", "GOWORK=off")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+	return stderr.String()
+}
@@ -0,0 +1,25 @@
+// Package testutil holds helpers shared by the analyzer tests.
+package testutil
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// SetFlag sets a flag of analyzer a for the rest of the test, restoring the
+// previous value on cleanup. Analyzer flags are package globals, so tests
+// that set them must not run in parallel.
+func SetFlag(t *testing.T, a *analysis.Analyzer, name, value string) {
+	t.Helper()
+
+	f := a.Flags.Lookup(name)
+	if f == nil {
+		t.Fatalf("%s has no flag %q", a.Name, name)
+	}
+	saved := f.Value.String()
+	if err := a.Flags.Set(name, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = a.Flags.Set(name, saved) })
+}
@@ -0,0 +1,186 @@
+package apisurface
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/params"
+	"golang.org/x/tools/go/analysis"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "apisurface",
+	Doc: "reports packages with a large exported API\n\n" +
+		"Counts the exported functions, methods, types, constants and variables " +
+		"of a package, and totals the parameters of exported function and " +
+		"method signatures (counted as params counts them). Methods count when " +
+		"both the method and its type are exported, including interface methods. " +
+		"Test files are not part of the API.",
+	Run: run,
+}
+
+var (
+	warnAt       int
+	failAt       int
+	paramsWarnAt int
+	paramsFailAt int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 60,
+		"exported identifier count at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 100,
+		"exported identifier count at or above this triggers a failure (red zone)")
+	Analyzer.Flags.IntVar(&paramsWarnAt, "params-warn", 120,
+		"total parameters of exported signatures at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&paramsFailAt, "params-fail", 200,
+		"total parameters of exported signatures at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+// surface tallies a package's exported API.
+type surface struct {
+	info                                *types.Info
+	funcs, methods, types, consts, vars int
+	params                              int
+}
+
+func (s surface) total() int {
+	return s.funcs + s.methods + s.types + s.consts + s.vars
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := defaults.Validate("apisurface"); err != nil {
+		return nil, err
+	}
+	paramsDefaults := common.Thresholds{WarnAt: paramsWarnAt, FailAt: paramsFailAt}
+	if err := paramsDefaults.Validate("apisurface-params"); err != nil {
+		return nil, err
+	}
+
+	s := surface{info: pass.TypesInfo}
+	var docs []*ast.CommentGroup
+	pos := token.NoPos
+	for _, file := range pass.Files {
+		filename := pass.Fset.Position(file.Pos()).Filename
+		if strings.HasSuffix(filename, "_test.go") || common.IsExcluded(filename) {
+			continue
+		}
+		docs = append(docs, file.Doc)
+		// Report at doc.go when there is one: that is where overrides go.
+		if pos == token.NoPos || filepath.Base(filename) == "doc.go" {
+			pos = file.Name.Pos()
+		}
+		for _, decl := range file.Decls {
+			s.add(decl)
+		}
+	}
+	if pos == token.NoPos {
+		return nil, nil
+	}
+
+	thresholds := common.ParseDocOverrides("apisurface", defaults, docs...)
+	if zone := thresholds.Classify(s.total()); zone != common.ZoneGreen {
+		pass.Report(analysis.Diagnostic{
+			Pos:      pos,
+			Category: zone.Category(),
+			Message: fmt.Sprintf(
+				"package %s exports %d identifiers (funcs: %d, methods: %d, types: %d, consts: %d, vars: %d) (warn: >=%d, fail: >=%d) [%s] "+
+					"(reduce by unexporting helpers callers do not need, or by splitting the package along its groups of related types)",
+				pass.Pkg.Name(), s.total(), s.funcs, s.methods, s.types, s.consts, s.vars,
+				thresholds.WarnAt, thresholds.FailAt, zone.Category()),
+		})
+	}
+
+	paramsThresholds := common.ParseDocOverrides("apisurface-params", paramsDefaults, docs...)
+	if zone := paramsThresholds.Classify(s.params); zone != common.ZoneGreen {
+		pass.Report(analysis.Diagnostic{
+			Pos:      pos,
+			Category: zone.Category(),
+			Message: fmt.Sprintf(
+				"package %s has %d parameters across exported signatures (warn: >=%d, fail: >=%d) [%s] "+
+					"(reduce by unexporting functions callers do not need, or by grouping recurring parameters into option structs)",
+				pass.Pkg.Name(), s.params,
+				paramsThresholds.WarnAt, paramsThresholds.FailAt, zone.Category()),
+		})
+	}
+
+	return nil, nil
+}
+
+// add tallies the exported identifiers declared by decl.
+func (s *surface) add(decl ast.Decl) {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if !d.Name.IsExported() {
+			return
+		}
+		if d.Recv == nil {
+			s.funcs++
+		} else if recvExported(d.Recv) {
+			s.methods++
+		} else {
+			return
+		}
+		s.params += params.CountParams(s.info, d.Type)
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			s.addSpec(d.Tok, spec)
+		}
+	}
+}
+
+func (s *surface) addSpec(tok token.Token, spec ast.Spec) {
+	switch sp := spec.(type) {
+	case *ast.TypeSpec:
+		if !sp.Name.IsExported() {
+			return
+		}
+		s.types++
+		if it, ok := sp.Type.(*ast.InterfaceType); ok {
+			s.addInterfaceMethods(it)
+		}
+	case *ast.ValueSpec:
+		for _, name := range sp.Names {
+			if !name.IsExported() {
+				continue
+			}
+			if tok == token.CONST {
+				s.consts++
+			} else {
+				s.vars++
+			}
+		}
+	}
+}
+
+func (s *surface) addInterfaceMethods(it *ast.InterfaceType) {
+	for _, field := range it.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			continue // embedded interface or constraint element
+		}
+		for _, name := range field.Names {
+			if name.IsExported() {
+				s.methods++
+				s.params += params.CountParams(s.info, ft)
+			}
+		}
+	}
+}
+
+// recvExported reports whether a method receiver's base type is exported.
+func recvExported(recv *ast.FieldList) bool {
+	if len(recv.List) == 0 {
+		return false
+	}
+	name := strings.TrimPrefix(common.ExprName(recv.List[0].Type), "*")
+	return ast.IsExported(name)
+}
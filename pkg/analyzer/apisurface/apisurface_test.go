@@ -0,0 +1,19 @@
+package apisurface_test
+
+import (
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/apisurface"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAPISurface(t *testing.T) {
+	testutil.SetFlag(t, apisurface.Analyzer, "warn", "5")
+	testutil.SetFlag(t, apisurface.Analyzer, "fail", "10")
+	testutil.SetFlag(t, apisurface.Analyzer, "params-warn", "6")
+	testutil.SetFlag(t, apisurface.Analyzer, "params-fail", "10")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, apisurface.Analyzer, "apisurface", "overridden")
+}
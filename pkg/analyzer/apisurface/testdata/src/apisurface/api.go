@@ -0,0 +1,37 @@
+package apisurface
+
+import "context"
+
+// Exported functions: 2, with 3 + 1 parameters (ctx is not counted).
+func Open(name string, flags, mode int) error { return nil }
+func Close(ctx context.Context, id int) error { return nil }
+
+// unexported functions are not part of the API.
+func helper(a, b, c, d int) {}
+
+// Store is an exported type with one exported method (2 parameters).
+type Store struct{}
+
+func (s *Store) Put(key, value string) {}
+func (s *Store) flush()                {}
+
+// internal is unexported; its exported methods are unreachable.
+type internal struct{}
+
+func (internal) Get(a, b, c int) {}
+
+// Getter is an exported interface with one exported method (1 parameter).
+// Its embedded interface is not a method of its own.
+type Getter interface {
+	error
+	Get(key string) string
+	close()
+}
+
+// Exported constants count, unexported ones do not.
+const (
+	Version = "1"
+	build   = 2
+)
+
+var cache = map[string]string{}
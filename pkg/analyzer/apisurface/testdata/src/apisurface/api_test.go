@@ -0,0 +1,6 @@
+package apisurface
+
+// Test files are not part of the API.
+func Fixture(a, b, c, d, e int) {}
+
+var Golden = 1
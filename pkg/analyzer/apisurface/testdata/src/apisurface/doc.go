@@ -0,0 +1,2 @@
+// Package apisurface exports 7 identifiers with 7 parameters in total.
+package apisurface // want `package apisurface exports 7 identifiers \(funcs: 2, methods: 2, types: 2, consts: 1, vars: 0\) \(warn: >=5, fail: >=10\) \[warning\] \(reduce by unexporting helpers callers do not need, or by splitting the package along its groups of related types\)` `package apisurface has 7 parameters across exported signatures \(warn: >=6, fail: >=10\) \[warning\] \(reduce by unexporting functions callers do not need, or by grouping recurring parameters into option structs\)`
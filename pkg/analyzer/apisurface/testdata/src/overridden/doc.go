@@ -0,0 +1,4 @@
+// Package overridden is a facade over a large protocol.
+//
+//complexity:apisurface:warn=20,fail=30 Mirrors every protocol message type.
+package overridden
@@ -0,0 +1,8 @@
+package overridden
+
+type A struct{}
+type B struct{}
+type C struct{}
+type D struct{}
+type E struct{}
+type F struct{}
@@ -0,0 +1,220 @@
+package chainlen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path"
+	"strings"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "chainlen",
+	Doc: "reports long selector and method call chains\n\n" +
+		"The length of a chain such as a.B().C().D() is the number of field and " +
+		"method selections in it (3 here), resolved with type information so " +
+		"package qualifiers like fmt.Sprintf do not count. Indexing and " +
+		"parentheses continue a chain. Selections on types matching -exempt " +
+		"(fluent builders) are not counted.",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+var (
+	warnAt int
+	failAt int
+	exempt string
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 4,
+		"chain length at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 6,
+		"chain length at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&exempt, "exempt", "",
+		"comma-separated glob patterns of receiver type names whose selections are not counted (e.g. *Builder,sql.Row)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := defaults.Validate("chainlen"); err != nil {
+		return nil, err
+	}
+	patterns := splitPatterns(exempt)
+
+	nodeFilter := []ast.Node{
+		(*ast.SelectorExpr)(nil),
+		(*ast.CallExpr)(nil),
+	}
+
+	insp.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		expr := n.(ast.Expr)
+		if continuesChain(expr, stack) {
+			return true
+		}
+		if common.IsExcluded(pass.Fset.Position(expr.Pos()).Filename) {
+			return false
+		}
+
+		length := chainLength(pass, expr, patterns)
+		thresholds := common.ParseDocOverrides("chainlen", defaults, enclosingDocs(stack)...)
+		report(pass, expr, length, thresholds)
+		return true
+	})
+
+	return nil, nil
+}
+
+func report(pass *analysis.Pass, expr ast.Expr, length int, thresholds common.Thresholds) {
+	zone := thresholds.Classify(length)
+	if zone == common.ZoneGreen {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      expr.Pos(),
+		End:      expr.End(),
+		Category: zone.Category(),
+		Message: fmt.Sprintf(
+			"chain %s has a length of %d (warn: >=%d, fail: >=%d) [%s] "+
+				"(reduce by assigning intermediate results to named variables so each step can be checked for errors and nil; fluent builder types can be exempted with -chainlen.exempt)",
+			render(expr), length, thresholds.WarnAt, thresholds.FailAt, zone.Category()),
+	})
+}
+
+// continuesChain reports whether expr is an inner link of a longer chain,
+// which is measured from its outermost expression instead.
+func continuesChain(expr ast.Expr, stack []ast.Node) bool {
+	child := ast.Node(expr)
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch p := stack[i].(type) {
+		case *ast.ParenExpr:
+			child = p
+			continue
+		case *ast.SelectorExpr:
+			return p.X == child
+		case *ast.CallExpr:
+			return p.Fun == child
+		case *ast.IndexExpr:
+			return p.X == child
+		case *ast.IndexListExpr:
+			return p.X == child
+		}
+		return false
+	}
+	return false
+}
+
+// chainLength counts the field and method selections along expr's chain.
+// Package-qualified identifiers have no selection and are not counted.
+func chainLength(pass *analysis.Pass, expr ast.Expr, patterns []string) int {
+	length := 0
+	for {
+		switch e := expr.(type) {
+		case *ast.CallExpr:
+			expr = e.Fun
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			if sel, ok := pass.TypesInfo.Selections[e]; ok && !isExempt(sel.Recv(), patterns) {
+				length++
+			}
+			expr = e.X
+		default:
+			return length
+		}
+	}
+}
+
+// isExempt reports whether recv's named type matches one of patterns, either
+// by its bare name (Builder) or qualified by package name (strings.Builder).
+func isExempt(recv types.Type, patterns []string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	if ptr, ok := types.Unalias(recv).(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	named, ok := types.Unalias(recv).(*types.Named)
+	if !ok {
+		return false
+	}
+	names := []string{named.Obj().Name()}
+	if pkg := named.Obj().Pkg(); pkg != nil {
+		names = append(names, pkg.Name()+"."+named.Obj().Name())
+	}
+	for _, p := range patterns {
+		for _, name := range names {
+			if matched, _ := path.Match(p, name); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func splitPatterns(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// render prints a chain with call arguments and indices elided, so that the
+// message stays readable for chains with large argument lists.
+func render(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		if len(e.Args) == 0 {
+			return render(e.Fun) + "()"
+		}
+		return render(e.Fun) + "(...)"
+	case *ast.ParenExpr:
+		return "(" + render(e.X) + ")"
+	case *ast.IndexExpr:
+		return render(e.X) + "[...]"
+	case *ast.IndexListExpr:
+		return render(e.X) + "[...]"
+	case *ast.SelectorExpr:
+		return render(e.X) + "." + e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	default:
+		return "(...)"
+	}
+}
+
+// enclosingDocs returns the doc comments of the declarations enclosing the
+// chain, innermost first, for override directives.
+func enclosingDocs(stack []ast.Node) []*ast.CommentGroup {
+	var docs []*ast.CommentGroup
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch d := stack[i].(type) {
+		case *ast.ValueSpec:
+			docs = append(docs, d.Doc)
+		case *ast.GenDecl:
+			return append(docs, d.Doc)
+		case *ast.FuncDecl:
+			return append(docs, d.Doc)
+		}
+	}
+	return docs
+}
@@ -0,0 +1,16 @@
+package chainlen_test
+
+import (
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/chainlen"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestChainLen(t *testing.T) {
+	testutil.SetFlag(t, chainlen.Analyzer, "exempt", "*Builder")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, chainlen.Analyzer, "chainlen")
+}
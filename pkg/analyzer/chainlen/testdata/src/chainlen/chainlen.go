@@ -0,0 +1,53 @@
+package chainlen
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Node struct {
+	Next  *Node
+	Items []*Node
+	Name  string
+}
+
+func (n *Node) Child() *Node    { return n.Next }
+func (n *Node) Get(i int) *Node { return n.Items[i] }
+func (n *Node) Label() string   { return n.Name }
+
+type QueryBuilder struct{}
+
+func (b *QueryBuilder) Where(string) *QueryBuilder { return b }
+func (b *QueryBuilder) Limit(int) *QueryBuilder    { return b }
+func (b *QueryBuilder) Build() *Node               { return nil }
+
+func short(n *Node) string {
+	// Package qualifiers are not links: 1 selection.
+	return strings.ToUpper(fmt.Sprint(n.Label()))
+}
+
+func long(n *Node) string {
+	return n.Child().Child().Child().Label() // want `chain n.Child\(\).Child\(\).Child\(\).Label\(\) has a length of 4 \(warn: >=4, fail: >=6\) \[warning\] \(reduce by assigning intermediate results to named variables so each step can be checked for errors and nil; fluent builder types can be exempted with -chainlen.exempt\)`
+}
+
+func mixed(n *Node) string {
+	// Fields, indexing and parentheses continue the chain.
+	return (n.Next.Items[0]).Get(1).Next.Child().Name // want `chain \(n.Next.Items\[...\]\).Get\(...\).Next.Child\(\).Name has a length of 6 \(warn: >=4, fail: >=6\) \[error\]`
+}
+
+func args(n *Node) {
+	// Chains inside arguments are measured on their own.
+	fmt.Println(n.Child(), n.Next.Next.Next.Next.Name) // want `chain n.Next.Next.Next.Next.Name has a length of 5`
+}
+
+func builder(b *QueryBuilder) string {
+	// QueryBuilder matches the exempt pattern; only Label counts.
+	return b.Where("a").Where("b").Limit(1).Build().Label()
+}
+
+//complexity:chainlen:warn=8,fail=10 Walks a fixed-shape tree.
+func overridden(n *Node) string {
+	return n.Child().Child().Child().Child().Child().Label()
+}
+
+var global = new(Node).Next.Next.Next.Next // want `chain new\(...\).Next.Next.Next.Next has a length of 4`
@@ -0,0 +1,339 @@
+package clones
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/cyclo"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/nestdepth"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "clones",
+	Doc: "reports duplicated blocks of code\n\n" +
+		"Fingerprints every block in a function body by its normalized syntax " +
+		"tree: node kinds and operators are kept, identifier names and literal " +
+		"values are ignored. Blocks of at least -warn nodes that share a " +
+		"fingerprint with another block in the package or in a module package " +
+		"it imports (directly or transitively) are reported; the standalone " +
+		"command also matches sibling packages that do not import each other. " +
+		"A clone inside a function already in cyclo's or nestdepth's yellow or " +
+		"red zone is raised one zone.",
+	Run:        run,
+	Requires:   []*analysis.Analyzer{cyclo.Analyzer, nestdepth.Analyzer},
+	FactTypes:  []analysis.Fact{new(Fact)},
+	ResultType: reflect.TypeOf((*Result)(nil)),
+}
+
+var (
+	warnAt int
+	failAt int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 40,
+		"duplicated block size in AST nodes at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 120,
+		"duplicated block size in AST nodes at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+// Fact lists the fingerprinted blocks of a package so that importers can
+// find clones of them.
+type Fact struct {
+	Blocks []Block
+}
+
+// Block is a fingerprinted block of code.
+type Block struct {
+	Hash string // fingerprint of the normalized syntax tree
+	Size int    // AST nodes
+	Func string // enclosing function
+	Pos  string // file:line of the opening brace
+}
+
+func (*Fact) AFact() {}
+
+func (f *Fact) String() string {
+	return fmt.Sprintf("clones(blocks=%d)", len(f.Blocks))
+}
+
+// block is a candidate block of the current package.
+type block struct {
+	Block
+	node       *ast.BlockStmt
+	funcDecl   *ast.FuncDecl
+	thresholds common.Thresholds // of funcDecl, after overrides
+	outer      *block            // nearest enclosing candidate, nil at the outermost
+}
+
+// ModuleReport declares that the driver calls ReportModule over the finished
+// graph, as the standalone command does. Reporting is then deferred to
+// ReportModule, which matches blocks across every package of the module;
+// other drivers see one package at a time, so run matches blocks against
+// the package and its imports only.
+var ModuleReport bool
+
+// Result holds the candidate blocks of a package, matched module-wide by
+// ReportModule.
+type Result struct {
+	blocks []*block
+	zones  map[*ast.FuncDecl]common.Zone
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := defaults.Validate("clones"); err != nil {
+		return nil, err
+	}
+	result := &Result{}
+	if !common.IsMainModule(pass.Module, pass.Pkg.Path()) {
+		// Dependencies are neither reported nor matched against.
+		return result, nil
+	}
+
+	// Fingerprints of every candidate here and in dependencies, in order of
+	// discovery: dependencies first, then this package in source order.
+	seen := make(map[string][]Block)
+	for _, pf := range pass.AllPackageFacts() {
+		if f, ok := pf.Fact.(*Fact); ok && pf.Package != pass.Pkg {
+			addBlocks(seen, f.Blocks)
+		}
+	}
+
+	result.blocks = collect(pass, defaults)
+	fact := &Fact{}
+	for _, b := range result.blocks {
+		fact.Blocks = append(fact.Blocks, b.Block)
+	}
+	addBlocks(seen, fact.Blocks)
+	if len(fact.Blocks) > 0 {
+		pass.ExportPackageFact(fact)
+	}
+
+	result.zones = complexityZones(pass)
+	if !ModuleReport {
+		for _, d := range result.diagnose(seen) {
+			pass.Report(d)
+		}
+	}
+	return result, nil
+}
+
+// ReportModule matches the blocks of each root package in graph against the
+// blocks of every module package analyzed, including sibling packages that
+// do not import each other, and appends diagnostics for the clones found to
+// the clones action of that package. It is a no-op when the clones analyzer
+// is not part of graph.
+func ReportModule(graph *checker.Graph) {
+	facts := common.PackageFacts[*Fact](graph, Analyzer)
+
+	// Copies are listed by package path, then in source order.
+	seen := make(map[string][]Block)
+	for _, path := range slices.Sorted(maps.Keys(facts)) {
+		addBlocks(seen, facts[path].Blocks)
+	}
+	for _, act := range common.RootActions(graph, Analyzer) {
+		result := act.Result.(*Result)
+		act.Diagnostics = append(act.Diagnostics, result.diagnose(seen)...)
+	}
+}
+
+func addBlocks(seen map[string][]Block, blocks []Block) {
+	for _, b := range blocks {
+		seen[b.Hash] = append(seen[b.Hash], b)
+	}
+}
+
+// diagnose returns the diagnostics of the blocks of r that share a
+// fingerprint in seen. Only the outermost duplicated block is reported.
+func (r *Result) diagnose(seen map[string][]Block) []analysis.Diagnostic {
+	var diagnostics []analysis.Diagnostic
+	for _, b := range r.blocks {
+		if !isClone(b, seen) || (b.outer != nil && isClone(b.outer, seen)) {
+			continue
+		}
+		if d, ok := diagnostic(b, seen[b.Hash], r.zones[b.funcDecl]); ok {
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	return diagnostics
+}
+
+func isClone(b *block, seen map[string][]Block) bool {
+	return len(seen[b.Hash]) > 1
+}
+
+// diagnostic describes the clone b of copies, raised one zone when its
+// function is outside cyclo's or nestdepth's green zone. It reports false
+// when b is green under its function's thresholds.
+func diagnostic(b *block, copies []Block, funcZone common.Zone) (analysis.Diagnostic, bool) {
+	zone := b.thresholds.Classify(b.Size)
+	if zone == common.ZoneGreen {
+		return analysis.Diagnostic{}, false
+	}
+	ranked := ""
+	if funcZone != common.ZoneGreen {
+		ranked = fmt.Sprintf("; raised because %s is already in the [%s] zone for cyclo or nestdepth", b.Func, funcZone.Category())
+		zone = min(zone+1, common.ZoneRed)
+	}
+
+	var others []string
+	for _, c := range copies {
+		if c != b.Block {
+			others = append(others, fmt.Sprintf("%s at %s", c.Func, c.Pos))
+		}
+	}
+
+	return analysis.Diagnostic{
+		Pos:      b.node.Lbrace,
+		Category: zone.Category(),
+		Message: fmt.Sprintf(
+			"block of %d nodes in %s duplicates %s (warn: >=%d, fail: >=%d) [%s] "+
+				"(reduce by extracting the shared logic into a function both call%s)",
+			b.Size, b.Func, strings.Join(others, ", "),
+			b.thresholds.WarnAt, b.thresholds.FailAt, zone.Category(), ranked),
+	}, true
+}
+
+// complexityZones returns the worse of the cyclo and nestdepth zones of
+// each function.
+func complexityZones(pass *analysis.Pass) map[*ast.FuncDecl]common.Zone {
+	zones := make(map[*ast.FuncDecl]common.Zone)
+	for fn, m := range pass.ResultOf[cyclo.Analyzer].(cyclo.Result) {
+		zones[fn] = max(zones[fn], m.Zone)
+	}
+	for fn, m := range pass.ResultOf[nestdepth.Analyzer].(nestdepth.Result) {
+		zones[fn] = max(zones[fn], m.Zone)
+	}
+	return zones
+}
+
+// collector gathers the candidate blocks of a package.
+type collector struct {
+	pass    *analysis.Pass
+	minSize int // smallest warn threshold of any function in the package
+	blocks  []*block
+}
+
+// collect fingerprints the blocks in the function bodies of the package.
+// Each function's clones thresholds apply after its overrides; blocks down
+// to the smallest warn threshold of any function are fingerprinted, so that
+// an override below -warn also finds copies in the other functions.
+func collect(pass *analysis.Pass, defaults common.Thresholds) []*block {
+	var funcs []*ast.FuncDecl
+	for _, file := range pass.Files {
+		if common.IsExcluded(pass.Fset.Position(file.Pos()).Filename) {
+			continue
+		}
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+				funcs = append(funcs, funcDecl)
+			}
+		}
+	}
+
+	c := &collector{pass: pass, minSize: defaults.WarnAt}
+	thresholds := make([]common.Thresholds, len(funcs))
+	for i, funcDecl := range funcs {
+		thresholds[i] = common.ParseOverrides(funcDecl, "clones", defaults)
+		c.minSize = min(c.minSize, thresholds[i].WarnAt)
+	}
+	for i, funcDecl := range funcs {
+		c.collectFunc(funcDecl, thresholds[i])
+	}
+	return c.blocks
+}
+
+func (c *collector) collectFunc(funcDecl *ast.FuncDecl, thresholds common.Thresholds) {
+	var stack []*block // candidates enclosing the current node
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if n == nil {
+			return true
+		}
+		for len(stack) > 0 && n.Pos() >= stack[len(stack)-1].node.End() {
+			stack = stack[:len(stack)-1]
+		}
+		body, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+		hash, size := fingerprint(body)
+		if size < c.minSize {
+			return true
+		}
+
+		posn := c.pass.Fset.Position(body.Lbrace)
+		b := &block{
+			Block: Block{
+				Hash: hash,
+				Size: size,
+				Func: common.FuncName(funcDecl),
+				Pos:  fmt.Sprintf("%s:%d", filepath.Base(posn.Filename), posn.Line),
+			},
+			node:       body,
+			funcDecl:   funcDecl,
+			thresholds: thresholds,
+		}
+		if len(stack) > 0 {
+			b.outer = stack[len(stack)-1]
+		}
+		stack = append(stack, b)
+		c.blocks = append(c.blocks, b)
+		return true
+	})
+}
+
+// fingerprint hashes the normalized syntax tree of n and returns the hash and
+// the number of nodes. Node kinds and operators are kept; identifier names
+// and literal values are dropped.
+func fingerprint(n ast.Node) (string, int) {
+	var sb strings.Builder
+	size := 0
+	ast.Inspect(n, func(n ast.Node) bool {
+		if n == nil {
+			sb.WriteString(")")
+			return true
+		}
+		size++
+		fmt.Fprintf(&sb, "(%T", n)
+		if op := operator(n); op != token.ILLEGAL {
+			sb.WriteString(op.String())
+		}
+		return true
+	})
+	sum := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(sum[:8]), size
+}
+
+// operator returns the operator or keyword token that distinguishes nodes of
+// the same kind, such as + and - in a binary expression.
+func operator(n ast.Node) token.Token {
+	switch e := n.(type) {
+	case *ast.BinaryExpr:
+		return e.Op
+	case *ast.UnaryExpr:
+		return e.Op
+	case *ast.AssignStmt:
+		return e.Tok
+	case *ast.IncDecStmt:
+		return e.Tok
+	case *ast.BranchStmt:
+		return e.Tok
+	case *ast.RangeStmt:
+		return e.Tok
+	}
+	return token.ILLEGAL
+}
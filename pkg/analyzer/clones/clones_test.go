@@ -0,0 +1,81 @@
+package clones_test
+
+import (
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/clones"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/cyclo"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestClones(t *testing.T) {
+	testutil.SetFlag(t, clones.Analyzer, "warn", "15")
+	testutil.SetFlag(t, clones.Analyzer, "fail", "40")
+	// Lower cyclo so that the loops in testdata put functions in its yellow
+	// zone and their clones are raised.
+	testutil.SetFlag(t, cyclo.Analyzer, "warn", "5")
+	testutil.SetFlag(t, cyclo.Analyzer, "fail", "8")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, clones.Analyzer, "clones")
+}
+
+func TestReportModule(t *testing.T) {
+	testutil.SetFlag(t, clones.Analyzer, "warn", "15")
+	testutil.SetFlag(t, clones.Analyzer, "fail", "40")
+	clones.ModuleReport = true
+	t.Cleanup(func() { clones.ModuleReport = false })
+
+	// Loaded as by the standalone command. The fixture is a module of its
+	// own whose third-party dependency, example.org/dep, is replaced by a
+	// local copy.
+	cfg := &packages.Config{
+		Mode: packages.LoadAllSyntax | packages.NeedModule,
+		Dir:  "testdata/module",
+		Env:  append(os.Environ(), "GOPROXY=off", "GOWORK=off"),
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		t.Fatalf("%d package errors", n)
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{clones.Analyzer}, pkgs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clones.ReportModule(graph)
+
+	var got []string
+	for act := range graph.All() {
+		if act.Analyzer != clones.Analyzer || !act.IsRoot {
+			continue
+		}
+		for _, d := range act.Diagnostics {
+			got = append(got, act.Package.Types.Name()+": "+d.Message)
+		}
+	}
+	sort.Strings(got)
+
+	const advice = " (warn: >=15, fail: >=40) [warning] (reduce by extracting the shared logic into a function both call)"
+	want := []string{
+		"invoices: block of 19 nodes in Due duplicates Total at orders.go:6" + advice,
+		"orders: block of 19 nodes in Total duplicates Due at invoices.go:3" + advice,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("diagnostics = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("diagnostic %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package invoices
+
+func Due(lines []int) int {
+	due := 0
+	for _, l := range lines {
+		if l > 0 {
+			due += l
+		}
+	}
+	return due
+}
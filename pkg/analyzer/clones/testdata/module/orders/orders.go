@@ -0,0 +1,18 @@
+// Package orders and package invoices do not import each other.
+package orders
+
+import "example.org/dep/sum"
+
+func Total(amounts []int) int {
+	total := 0
+	for _, a := range amounts {
+		if a > 0 {
+			total += a
+		}
+	}
+	return total
+}
+
+func Checked(amounts []int) bool {
+	return Total(amounts) == sum.Positive(amounts)
+}
@@ -0,0 +1,93 @@
+package clones // want package:"clones\\(blocks=15\\)"
+
+import "ext.pkg/orig"
+
+// positive is orig.Sum with different names and literals.
+func positive(vals []int) int { // want `block of 19 nodes in positive duplicates Sum at orig.go:4 \(warn: >=15, fail: >=40\) \[warning\] \(reduce by extracting the shared logic into a function both call\)`
+	acc := 0
+	for _, v := range vals {
+		if v > 1 {
+			acc += v
+		}
+	}
+	return acc
+}
+
+// different uses another operator, so it is not a clone.
+func different(vals []int) int {
+	acc := 0
+	for _, v := range vals {
+		if v < 0 {
+			acc -= v
+		}
+	}
+	return acc
+}
+
+func wrapper(xs []int) int {
+	return orig.Sum(xs)
+}
+
+func count(m map[string][]int) int {
+	n := 0
+	for k, vs := range m { // want `block of 15 nodes in count duplicates tally at clones.go:50, silenced at clones.go:66 \(warn: >=15, fail: >=40\) \[warning\]`
+		for _, v := range vs {
+			if v == len(k) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// tally repeats count's loop in a function cyclo already warns about, so its
+// copy is raised to an error.
+func tally(m map[string][]int, strict bool) int {
+	n := 0
+	if strict {
+		n = -1
+	}
+	for k, vs := range m { // want `block of 15 nodes in tally duplicates count at clones.go:33, silenced at clones.go:66 \(warn: >=15, fail: >=40\) \[error\] \(reduce by extracting the shared logic into a function both call; raised because tally is already in the \[warning\] zone for cyclo or nestdepth\)`
+		for _, w := range vs {
+			if w == len(k) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+//complexity:clones:warn=50,fail=60 Kept apart on purpose.
+func silenced(m map[string][]int) int {
+	if m == nil {
+		return 0
+	}
+	total := 0
+	for key, list := range m {
+		for _, item := range list {
+			if item == len(key) {
+				total++
+			}
+		}
+	}
+	return total
+}
+
+// clamp's override lowers the block size threshold below -warn, so its body
+// is reported as a copy of limit's.
+//
+//complexity:clones:warn=8,fail=40 Small, but the bounds must stay in sync.
+func clamp(v int) int { // want `block of 11 nodes in clamp duplicates limit at clones.go:\d+ \(warn: >=8, fail: >=40\) \[warning\]`
+	if v > 100 {
+		v = 100
+	}
+	return v
+}
+
+// limit's copy is below the default -warn and stays green.
+func limit(n int) int {
+	if n > 255 {
+		n = 255
+	}
+	return n
+}
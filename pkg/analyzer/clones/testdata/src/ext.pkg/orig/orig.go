@@ -0,0 +1,12 @@
+package orig
+
+// Sum adds the positive values.
+func Sum(xs []int) int {
+	total := 0
+	for _, x := range xs {
+		if x > 0 {
+			total += x
+		}
+	}
+	return total
+}
@@ -1,22 +1,92 @@
 package common
 
-import "go/ast"
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+)
 
-// IsExemptContextParam reports whether a parameter is the idiomatic Go context
-// argument: named ctx with type context.Context. Such parameters are omitted
-// from params complexity counts because they are boilerplate, not decision load.
-func IsExemptContextParam(name string, typ ast.Expr) bool {
-	return name == "ctx" && isContextContextType(typ)
+// exemptTypePresets are the named groups of boilerplate parameter types
+// accepted in a ParseParamExemption list.
+var exemptTypePresets = map[string][]string{
+	"testing": {"*testing.T", "*testing.B", "*testing.F", "testing.TB"},
+	"http":    {"*net/http.Request", "net/http.ResponseWriter"},
 }
 
-func isContextContextType(typ ast.Expr) bool {
-	sel, ok := typ.(*ast.SelectorExpr)
-	if !ok {
-		return false
+// ParamExemption selects the parameters omitted from parameter counts
+// because they are boilerplate, not decision load. The idiomatic
+// ctx context.Context is always exempt; the policy can widen that to any
+// name and add further types.
+type ParamExemption struct {
+	// AnyContextName exempts context.Context parameters of any name,
+	// including _ and unnamed ones, not only those named ctx.
+	AnyContextName bool
+	// Types are further exempt types under any name, fully qualified as
+	// printed by types.TypeString: *net/http.Request, example.com/log.Logger.
+	Types []string
+}
+
+// ParseParamExemption builds a ParamExemption from flag values. names is the
+// context name policy, "ctx" or "any". typeList is a comma-separated list of
+// fully qualified type names and the presets "testing" (*testing.T, B, F and
+// testing.TB) and "http" (*http.Request and http.ResponseWriter).
+func ParseParamExemption(names, typeList string) (ParamExemption, error) {
+	var e ParamExemption
+	switch names {
+	case "ctx":
+	case "any":
+		e.AnyContextName = true
+	default:
+		return e, fmt.Errorf("invalid context name policy %q (want ctx or any)", names)
 	}
-	pkg, ok := sel.X.(*ast.Ident)
-	if !ok || pkg.Name != "context" {
+	for _, name := range strings.Split(typeList, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if preset, ok := exemptTypePresets[name]; ok {
+			e.Types = append(e.Types, preset...)
+			continue
+		}
+		if !isQualifiedTypeName(name) {
+			return e, fmt.Errorf("invalid exempt type %q (want testing, http or a qualified type such as *net/http.Request)", name)
+		}
+		e.Types = append(e.Types, name)
+	}
+	return e, nil
+}
+
+// isQualifiedTypeName reports whether name has the form [*]path.Name.
+func isQualifiedTypeName(name string) bool {
+	name = strings.TrimPrefix(name, "*")
+	dot := strings.LastIndex(name, ".")
+	return dot > 0 && dot > strings.LastIndex(name, "/") && dot < len(name)-1
+}
+
+// IsExempt reports whether a parameter of type typ is exempt. name is the
+// parameter's name, or nil when the parameter is unnamed. Types are resolved
+// with info, so aliased imports (stdctx "context") and type aliases of an
+// exempt type are recognized.
+func (e ParamExemption) IsExempt(info *types.Info, name *ast.Ident, typ ast.Expr) bool {
+	t := info.TypeOf(typ)
+	if t == nil {
 		return false
 	}
-	return sel.Sel.Name == "Context"
+	qualified := qualifiedTypeName(t)
+	if qualified == "context.Context" {
+		return e.AnyContextName || (name != nil && name.Name == "ctx")
+	}
+	return slices.Contains(e.Types, qualified)
+}
+
+// qualifiedTypeName returns t as matched against ParamExemption.Types, with
+// aliases resolved, also behind a pointer.
+func qualifiedTypeName(t types.Type) string {
+	t = types.Unalias(t)
+	if ptr, ok := t.(*types.Pointer); ok {
+		return "*" + types.TypeString(types.Unalias(ptr.Elem()), nil)
+	}
+	return types.TypeString(t, nil)
 }
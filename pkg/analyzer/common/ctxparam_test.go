@@ -2,55 +2,192 @@ package common
 
 import (
 	"go/ast"
+	"go/importer"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"testing"
 )
 
-func parseFunc(t *testing.T, src string) *ast.FuncDecl {
+// checkFunc type-checks src, which declares func f after the given imports,
+// and returns f with the type information.
+func checkFunc(t *testing.T, imports, src string) (*ast.FuncDecl, *types.Info) {
 	t.Helper()
 
-	file, err := parser.ParseFile(token.NewFileSet(), "test.go", "package p\n"+src, 0)
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", "package p\n"+imports+"\n"+src, 0)
 	if err != nil {
 		t.Fatalf("parse: %v", err)
 	}
-	return file.Decls[0].(*ast.FuncDecl)
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("p", fset, []*ast.File{file}, info); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == "f" {
+			return fn, info
+		}
+	}
+	t.Fatal("no func f")
+	return nil, nil
 }
 
-func TestIsExemptContextParam(t *testing.T) {
+func TestParamExemption(t *testing.T) {
 	tests := []struct {
-		name  string
-		param string
-		typ   string
-		want  bool
+		name    string
+		imports string
+		src     string
+		names   string
+		types   string
+		want    []bool
 	}{
-		{name: "ctx context.Context", param: "ctx", typ: "context.Context", want: true},
-		{name: "wrong name", param: "c", typ: "context.Context", want: false},
-		{name: "wrong type selector", param: "ctx", typ: "context.CancelFunc", want: false},
-		{name: "unqualified Context", param: "ctx", typ: "Context", want: false},
-		{name: "pointer to context.Context", param: "ctx", typ: "*context.Context", want: false},
+		{
+			name:    "ctx context.Context",
+			imports: `import "context"`,
+			src:     "func f(ctx context.Context, n int) {}",
+			names:   "ctx",
+			want:    []bool{true, false},
+		},
+		{
+			name:    "wrong name",
+			imports: `import "context"`,
+			src:     "func f(c context.Context) {}",
+			names:   "ctx",
+			want:    []bool{false},
+		},
+		{
+			name:    "wrong type",
+			imports: `import "context"`,
+			src:     "func f(ctx context.CancelFunc) {}",
+			names:   "ctx",
+			want:    []bool{false},
+		},
+		{
+			name:    "local Context type",
+			imports: "type Context interface{}",
+			src:     "func f(ctx Context) {}",
+			names:   "ctx",
+			want:    []bool{false},
+		},
+		{
+			name:    "pointer to context.Context",
+			imports: `import "context"`,
+			src:     "func f(ctx *context.Context) {}",
+			names:   "ctx",
+			want:    []bool{false},
+		},
+		{
+			name:    "grouped",
+			imports: `import "context"`,
+			src:     "func f(ctx, cancel context.Context) {}",
+			names:   "ctx",
+			want:    []bool{true, false},
+		},
+		{
+			name:    "aliased import",
+			imports: `import stdctx "context"`,
+			src:     "func f(ctx stdctx.Context) {}",
+			names:   "ctx",
+			want:    []bool{true},
+		},
+		{
+			name:    "type alias",
+			imports: "import \"context\"\ntype Ctx = context.Context",
+			src:     "func f(ctx Ctx) {}",
+			names:   "ctx",
+			want:    []bool{true},
+		},
+		{
+			name:    "any name",
+			imports: `import "context"`,
+			src:     "func f(c context.Context, _ context.Context, n int) {}",
+			names:   "any",
+			want:    []bool{true, true, false},
+		},
+		{
+			name:    "unnamed with any name",
+			imports: `import "context"`,
+			src:     "func f(context.Context, int) {}",
+			names:   "any",
+			want:    []bool{true, false},
+		},
+		{
+			name:    "http preset",
+			imports: `import "net/http"`,
+			src:     "func f(w http.ResponseWriter, r *http.Request, v http.Request) {}",
+			names:   "ctx",
+			types:   "http",
+			want:    []bool{true, true, false},
+		},
+		{
+			name:    "testing preset",
+			imports: `import "testing"`,
+			src:     "func f(t *testing.T, tb testing.TB, m *testing.M) {}",
+			names:   "ctx",
+			types:   "testing",
+			want:    []bool{true, true, false},
+		},
+		{
+			name:    "qualified type",
+			imports: `import "strings"`,
+			src:     "func f(b *strings.Builder, r *strings.Reader) {}",
+			names:   "ctx",
+			types:   "*strings.Builder",
+			want:    []bool{true, false},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			fn := parseFunc(t, "func f("+tc.param+" "+tc.typ+") {}")
-			field := fn.Type.Params.List[0]
-			got := IsExemptContextParam(field.Names[0].Name, field.Type)
-			if got != tc.want {
-				t.Fatalf("IsExemptContextParam(%q, %q) = %v, want %v", tc.param, tc.typ, got, tc.want)
+			exemption, err := ParseParamExemption(tc.names, tc.types)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fn, info := checkFunc(t, tc.imports, tc.src)
+
+			var got []bool
+			for _, field := range fn.Type.Params.List {
+				if len(field.Names) == 0 {
+					got = append(got, exemption.IsExempt(info, nil, field.Type))
+				}
+				for _, name := range field.Names {
+					got = append(got, exemption.IsExempt(info, name, field.Type))
+				}
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d params, want %d", len(got), len(tc.want))
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("param %d: IsExempt() = %v, want %v", i, got[i], tc.want[i])
+				}
 			}
 		})
 	}
 }
 
-func TestIsExemptContextParamGrouped(t *testing.T) {
-	fn := parseFunc(t, "func f(ctx, cancel context.Context) {}")
-	field := fn.Type.Params.List[0]
-
-	if !IsExemptContextParam(field.Names[0].Name, field.Type) {
-		t.Fatal("expected ctx to be exempt")
+func TestParseParamExemption(t *testing.T) {
+	tests := []struct {
+		names, types string
+		wantErr      bool
+	}{
+		{names: "ctx"},
+		{names: "any", types: "testing, http,*example.com/log.Logger"},
+		{names: "all", wantErr: true},
+		{names: "ctx", types: "Logger", wantErr: true},
+		{names: "ctx", types: "example.com/log", wantErr: true},
+		{names: "ctx", types: "log.", wantErr: true},
 	}
-	if IsExemptContextParam(field.Names[1].Name, field.Type) {
-		t.Fatal("expected cancel not to be exempt")
+
+	for _, tc := range tests {
+		_, err := ParseParamExemption(tc.names, tc.types)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseParamExemption(%q, %q) error = %v, want error %v", tc.names, tc.types, err, tc.wantErr)
+		}
 	}
 }
@@ -3,22 +3,25 @@ package common
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
 )
 
-// ErrGuardCallExprs returns call expressions nested in the return statement of an
-// idiomatic error guard if. Fanout omits these as boilerplate error wiring.
-func ErrGuardCallExprs(body *ast.BlockStmt) map[*ast.CallExpr]struct{} {
+// ErrGuardCallExprs returns call expressions nested in the body of an
+// idiomatic error guard if (or an enabled guard pattern): the calls in its
+// return statement, the logging call of a logged guard, the arguments of a
+// panic guard. Fanout omits these as boilerplate error wiring. info is
+// passed on to IsErrGuard and ResultGuards; node is a function body, or the
+// declaration or literal owning it so that guards returning through named
+// results are found as well.
+func ErrGuardCallExprs(info *types.Info, node ast.Node) map[*ast.CallExpr]struct{} {
 	excluded := make(map[*ast.CallExpr]struct{})
-	ast.Inspect(body, func(n ast.Node) bool {
+	resultGuards := ResultGuards(info, node)
+	ast.Inspect(node, func(n ast.Node) bool {
 		ifStmt, ok := n.(*ast.IfStmt)
-		if !ok || !IsErrGuard(ifStmt) {
+		if !ok || !(IsErrGuard(info, ifStmt) || resultGuards[ifStmt]) {
 			return true
 		}
-		ret, ok := ifStmt.Body.List[0].(*ast.ReturnStmt)
-		if !ok {
-			return true
-		}
-		ast.Inspect(ret, func(n2 ast.Node) bool {
+		ast.Inspect(ifStmt.Body, func(n2 ast.Node) bool {
 			if call, ok := n2.(*ast.CallExpr); ok {
 				excluded[call] = struct{}{}
 			}
@@ -37,12 +40,33 @@ func ErrGuardCallExprs(body *ast.BlockStmt) map[*ast.CallExpr]struct{} {
 //  4. That statement is a return
 //  5. All return values except the last are zero-value expressions
 //  6. The last return value is either the same identifier or a function call
-func IsErrGuard(ifStmt *ast.IfStmt) bool {
-	errName := identNotNil(ifStmt.Cond)
-	if errName == "" {
+//
+// With type information, the identifier's type must implement error, and
+// the last return value may also be a package-level error variable (a
+// sentinel such as ErrNotFound or io.EOF) or a composite literal of an error
+// type (&MyErr{...}); calls must return an error. When info is nil, only the
+// syntax is checked.
+//
+// The guard patterns enabled in Guards (comma-ok, nil check, logged and
+// panic guards) are accepted as well. None of them may have an else clause.
+//
+// Guards returning through named results, such as `if err != nil { return }`,
+// depend on the enclosing function's signature; see ResultGuards. Loop
+// guards depend on the enclosing statements; see LoopGuards.
+func IsErrGuard(info *types.Info, ifStmt *ast.IfStmt) bool {
+	if ifStmt.Else != nil {
 		return false
 	}
-	if ifStmt.Else != nil {
+	return isErrGuard(info, ifStmt) || isOptInGuard(info, ifStmt)
+}
+
+// isErrGuard matches the error guard itself, rules 1 and 3 to 6 above.
+func isErrGuard(info *types.Info, ifStmt *ast.IfStmt) bool {
+	errIdent := identNotNil(ifStmt.Cond)
+	if errIdent == nil {
+		return false
+	}
+	if info != nil && !implementsError(info.TypeOf(errIdent)) {
 		return false
 	}
 	if len(ifStmt.Body.List) != 1 {
@@ -55,45 +79,46 @@ func IsErrGuard(ifStmt *ast.IfStmt) bool {
 	if len(retStmt.Results) == 0 {
 		return false
 	}
-	return isErrReturn(retStmt.Results, errName)
+	return isErrReturn(info, retStmt.Results, errIdent)
 }
 
 // identNotNil checks if the condition is `<ident> != nil` (or `nil != <ident>`).
-// Returns the identifier name, or "" if the condition doesn't match.
-func identNotNil(cond ast.Expr) string {
+// Returns the identifier, or nil if the condition doesn't match.
+func identNotNil(cond ast.Expr) *ast.Ident {
 	binExpr, ok := cond.(*ast.BinaryExpr)
 	if !ok || binExpr.Op != token.NEQ {
-		return ""
+		return nil
 	}
+	return nilComparand(binExpr)
+}
 
+// nilComparand returns the identifier compared with nil in `<ident> op nil`
+// or `nil op <ident>`, or nil if binExpr has another shape.
+func nilComparand(binExpr *ast.BinaryExpr) *ast.Ident {
 	xIdent, xIsIdent := binExpr.X.(*ast.Ident)
 	yIdent, yIsIdent := binExpr.Y.(*ast.Ident)
 
-	// <ident> != nil
+	// <ident> op nil
 	if xIsIdent && yIsIdent && yIdent.Name == "nil" {
-		return xIdent.Name
+		return xIdent
 	}
-	// nil != <ident>
+	// nil op <ident>
 	if xIsIdent && xIdent.Name == "nil" && yIsIdent {
-		return yIdent.Name
+		return yIdent
 	}
-	return ""
+	return nil
 }
 
 // isErrReturn checks that all return values except the last are zero-value
-// expressions, and the last is either the error identifier or a function call.
-func isErrReturn(results []ast.Expr, errName string) bool {
+// expressions, and the last is an error value for the guarded identifier.
+func isErrReturn(info *types.Info, results []ast.Expr, errIdent *ast.Ident) bool {
 	last := results[len(results)-1]
 
-	// Check the last return value is the error variable or a function call.
-	switch v := last.(type) {
-	case *ast.Ident:
-		if v.Name != errName {
+	if info == nil {
+		if !isErrSyntax(last, errIdent) {
 			return false
 		}
-	case *ast.CallExpr:
-		// Any function call is allowed (fmt.Errorf, errors.New, etc.)
-	default:
+	} else if !isErrValue(info, last, errIdent) {
 		return false
 	}
 
@@ -107,6 +132,58 @@ func isErrReturn(results []ast.Expr, errName string) bool {
 	return true
 }
 
+// isErrSyntax reports whether expr is the error identifier or a function
+// call (fmt.Errorf, errors.New, etc.).
+func isErrSyntax(expr ast.Expr, errIdent *ast.Ident) bool {
+	switch v := expr.(type) {
+	case *ast.Ident:
+		return v.Name == errIdent.Name
+	case *ast.CallExpr:
+		return true
+	default:
+		return false
+	}
+}
+
+// isErrValue reports whether expr is the guarded error itself, a
+// package-level error variable, or a call or composite literal (possibly
+// behind &) whose type implements error.
+func isErrValue(info *types.Info, expr ast.Expr, errIdent *ast.Ident) bool {
+	switch v := expr.(type) {
+	case *ast.Ident:
+		obj := info.Uses[v]
+		return obj == info.ObjectOf(errIdent) || isErrSentinel(obj)
+	case *ast.SelectorExpr:
+		return isErrSentinel(info.Uses[v.Sel])
+	case *ast.CallExpr, *ast.CompositeLit:
+		return implementsError(info.TypeOf(v))
+	case *ast.UnaryExpr:
+		_, isLit := v.X.(*ast.CompositeLit)
+		return v.Op == token.AND && isLit && implementsError(info.TypeOf(v))
+	default:
+		return false
+	}
+}
+
+// isErrSentinel reports whether obj is a package-level variable of a type
+// implementing error, in this package or another.
+func isErrSentinel(obj types.Object) bool {
+	v, ok := obj.(*types.Var)
+	if !ok || v.Pkg() == nil || v.Parent() != v.Pkg().Scope() {
+		return false
+	}
+	return implementsError(v.Type())
+}
+
+// implementsError reports whether t implements the error interface.
+func implementsError(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	return types.Implements(t, errorType)
+}
+
 // isZeroValue reports whether an expression is a zero-value literal:
 // nil, 0, "", false, or Type{}.
 func isZeroValue(expr ast.Expr) bool {
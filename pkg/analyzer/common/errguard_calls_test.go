@@ -37,7 +37,7 @@ func f() error {
 		t.Fatal("function f not found")
 	}
 
-	excluded := ErrGuardCallExprs(fn.Body)
+	excluded := ErrGuardCallExprs(nil, fn.Body)
 	var excludedNames []string
 	ast.Inspect(fn.Body, func(n ast.Node) bool {
 		call, ok := n.(*ast.CallExpr)
@@ -4,6 +4,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"testing"
 )
 
@@ -150,10 +151,112 @@ func TestIsErrGuard(t *testing.T) {
 				t.Fatal("no IfStmt found")
 			}
 
-			got := IsErrGuard(ifStmt)
+			got := IsErrGuard(nil, ifStmt)
 			if got != tt.want {
 				t.Errorf("IsErrGuard() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestIsErrGuardTyped(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{
+			name: "error variable",
+			code: `if err != nil { return nil, err }`,
+			want: true,
+		},
+		{
+			name: "concrete error type",
+			code: `if me != nil { return nil, me }`,
+			want: true,
+		},
+		{
+			name: "pointer that is not an error",
+			code: `if p != nil { return nil, p }`,
+			want: false,
+		},
+		{
+			name: "package-level sentinel",
+			code: `if err != nil { return nil, ErrBadInput }`,
+			want: true,
+		},
+		{
+			name: "composite literal of an error type",
+			code: `if err != nil { return nil, &MyErr{msg: "bad"} }`,
+			want: true,
+		},
+		{
+			name: "value composite literal of an error type",
+			code: `if err != nil { return nil, ValErr{} }`,
+			want: true,
+		},
+		{
+			name: "wrapped error",
+			code: `if err != nil { return nil, wrap(err) }`,
+			want: true,
+		},
+		{
+			name: "call not returning an error",
+			code: `if err != nil { return nil, describe(err) }`,
+			want: false,
+		},
+		{
+			name: "other local error",
+			code: `if err != nil { return nil, other }`,
+			want: false,
+		},
+		{
+			name: "package-level variable that is not an error",
+			code: `if err != nil { return nil, notAnError }`,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := `package p
+type MyErr struct{ msg string }
+func (e *MyErr) Error() string { return e.msg }
+type ValErr struct{}
+func (ValErr) Error() string { return "" }
+var ErrBadInput error = &MyErr{}
+var notAnError = new(int)
+func wrap(err error) error { return err }
+func describe(err error) *int { return nil }
+func f(err, other error, me *MyErr, p *int) (*int, any) {
+` + tt.code + `
+	return nil, nil
+}`
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "test.go", src, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			info := &types.Info{
+				Types: make(map[ast.Expr]types.TypeAndValue),
+				Defs:  make(map[*ast.Ident]types.Object),
+				Uses:  make(map[*ast.Ident]types.Object),
+			}
+			if _, err := new(types.Config).Check("p", fset, []*ast.File{f}, info); err != nil {
+				t.Fatal(err)
+			}
+
+			var ifStmt *ast.IfStmt
+			ast.Inspect(f, func(n ast.Node) bool {
+				if is, ok := n.(*ast.IfStmt); ok && ifStmt == nil {
+					ifStmt = is
+				}
+				return ifStmt == nil
+			})
+
+			if got := IsErrGuard(info, ifStmt); got != tt.want {
+				t.Errorf("IsErrGuard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package common
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// FuncLits enables measuring every function literal as a unit of its own.
+// DetachFuncLits additionally removes literal bodies from the counts of the
+// function enclosing them; it has no effect unless FuncLits is set. Like
+// ExcludePatterns, every per-function analyzer registers flags pointing to
+// these variables, so setting them on any one analyzer applies to all.
+var (
+	FuncLits       bool
+	DetachFuncLits bool
+)
+
+// RegisterFuncLitFlags registers the shared -funclits and -funclits-detach
+// flags on a per-function analyzer.
+func RegisterFuncLitFlags(a *analysis.Analyzer) {
+	a.Flags.BoolVar(&FuncLits, "funclits", false,
+		"also measure each function literal as its own unit (named like Outer.func1)")
+	a.Flags.BoolVar(&DetachFuncLits, "funclits-detach", false,
+		"with -funclits, leave function literal bodies out of the enclosing function's counts")
+}
+
+// FuncLitsDetached reports whether function literal bodies belong to their
+// own units only and must be skipped when measuring the enclosing function.
+func FuncLitsDetached() bool {
+	return FuncLits && DetachFuncLits
+}
+
+// FuncLitUnit is a function literal measured on its own.
+type FuncLitUnit struct {
+	Lit *ast.FuncLit
+	// Name is synthesized the way the Go runtime names closures: literals in
+	// a function Outer are Outer.func1, Outer.func2, ... in source order, and
+	// literals nested in Outer.func1 are Outer.func1.1, Outer.func1.2, ...
+	// Literals in a package-level variable take the variable's name.
+	Name string
+	// Docs are searched in order for override directives: the comment group
+	// ending on the line before the literal, when it lies inside the
+	// enclosing function body or variable value, then, for literals directly
+	// in a package-level declaration, the spec and declaration doc comments.
+	Docs []*ast.CommentGroup
+}
+
+// PassFuncLitUnits returns the function literal units of every file in the
+// pass that is not excluded, or nil unless FuncLits is set.
+func PassFuncLitUnits(pass *analysis.Pass) []FuncLitUnit {
+	if !FuncLits {
+		return nil
+	}
+	var units []FuncLitUnit
+	for _, file := range pass.Files {
+		if IsExcluded(pass.Fset.Position(file.Pos()).Filename) {
+			continue
+		}
+		units = append(units, FuncLitUnits(pass.Fset, file)...)
+	}
+	return units
+}
+
+// FuncLitUnits returns every function literal in file, outermost first.
+func FuncLitUnits(fset *token.FileSet, file *ast.File) []FuncLitUnit {
+	c := funcLitCollector{fset: fset, above: make(map[int]*ast.CommentGroup)}
+	for _, group := range file.Comments {
+		c.above[fset.Position(group.End()).Line+1] = group
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Body != nil {
+				c.collect(d.Body, FuncName(d)+".func", nil)
+			}
+		case *ast.GenDecl:
+			c.collectGenDecl(d)
+		}
+	}
+	return c.units
+}
+
+type funcLitCollector struct {
+	fset  *token.FileSet
+	above map[int]*ast.CommentGroup // comment group by the line following it
+	units []FuncLitUnit
+}
+
+// collectGenDecl collects the literals in package-level variable values,
+// named after the variable each value initializes.
+func (c *funcLitCollector) collectGenDecl(decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		docs := []*ast.CommentGroup{vs.Doc, decl.Doc}
+		for i, value := range vs.Values {
+			name := vs.Names[min(i, len(vs.Names)-1)].Name
+			c.collect(value, name+".func", docs)
+		}
+	}
+}
+
+// collect numbers the outermost literals in node after prefix and recurses
+// into each literal's body for the next level. docs are appended to the
+// override docs of the outermost literals only.
+func (c *funcLitCollector) collect(node ast.Node, prefix string, docs []*ast.CommentGroup) {
+	n := 0
+	ast.Inspect(node, func(n2 ast.Node) bool {
+		lit, ok := n2.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		n++
+		name := prefix + strconv.Itoa(n)
+		c.units = append(c.units, FuncLitUnit{
+			Lit:  lit,
+			Name: name,
+			Docs: append([]*ast.CommentGroup{c.commentAbove(lit, node)}, docs...),
+		})
+		c.collect(lit.Body, name+".", nil)
+		return false
+	})
+}
+
+// commentAbove returns the comment group ending on the line before lit, if
+// it lies within node, the body or value enclosing lit. A group before node,
+// such as the doc comment of a one-line function, belongs to the enclosing
+// declaration and is not the literal's.
+func (c *funcLitCollector) commentAbove(lit *ast.FuncLit, node ast.Node) *ast.CommentGroup {
+	group := c.above[c.fset.Position(lit.Pos()).Line]
+	if group == nil || group.Pos() < node.Pos() {
+		return nil
+	}
+	return group
+}
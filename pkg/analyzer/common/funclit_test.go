@@ -0,0 +1,103 @@
+package common
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func TestFuncLitUnits(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "no literals",
+			src:  "func Foo() {}",
+			want: nil,
+		},
+		{
+			name: "numbered in source order",
+			src:  "func Foo() { a := func() {}; b := func() {}; _, _ = a, b }",
+			want: []string{"Foo.func1", "Foo.func2"},
+		},
+		{
+			name: "nested literals",
+			src:  "func Foo() { go func() { defer func() {}() }(); _ = func() {} }",
+			want: []string{"Foo.func1", "Foo.func1.1", "Foo.func2"},
+		},
+		{
+			name: "method",
+			src:  "type T struct{}\nfunc (t *T) Bar() { _ = func() {} }",
+			want: []string{"*T.Bar.func1"},
+		},
+		{
+			name: "package-level variable",
+			src:  "var handler = func() {}\nvar routes = []func(){func() {}, func() {}}\nvar a, b = func() {}, func() {}",
+			want: []string{"handler.func1", "routes.func1", "routes.func2", "a.func1", "b.func1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", "package p\n"+tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, unit := range FuncLitUnits(fset, file) {
+				got = append(got, unit.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FuncLitUnits() names = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncLitUnitsOverrides(t *testing.T) {
+	src := `package p
+
+// Handler doc.
+//complexity:cyclo:warn=20,fail=30
+var handler = func() {}
+
+func Foo() {
+	//complexity:cyclo:warn=3,fail=4
+	run(func() {})
+	run(func() {})
+}
+
+//complexity:cyclo:warn=1,fail=2
+func OneLine() { run(func() {}) }
+
+func run(func()) {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	units := FuncLitUnits(fset, file)
+	if len(units) != 4 {
+		t.Fatalf("got %d units, want 4", len(units))
+	}
+
+	defaults := Thresholds{WarnAt: 10, FailAt: 15}
+	want := []Thresholds{
+		{WarnAt: 20, FailAt: 30},
+		{WarnAt: 3, FailAt: 4},
+		defaults,
+		// OneLine's doc comment is not its literal's.
+		defaults,
+	}
+	for i, unit := range units {
+		got := ParseDocOverrides("cyclo", defaults, unit.Docs...)
+		if got != want[i] {
+			t.Errorf("%s thresholds = %+v, want %+v", unit.Name, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// RootActions returns the successful actions of analyzer a on the root
+// packages of graph, for metrics reported over the finished graph.
+func RootActions(graph *checker.Graph, a *analysis.Analyzer) []*checker.Action {
+	var roots []*checker.Action
+	for act := range graph.All() {
+		if act.Analyzer == a && act.IsRoot && act.Err == nil {
+			roots = append(roots, act)
+		}
+	}
+	return roots
+}
+
+// PackageFacts returns the package facts of type F known to the successful
+// actions of analyzer a in graph, by package path: those of the root
+// packages and of every dependency analyzed for them.
+func PackageFacts[F analysis.Fact](graph *checker.Graph, a *analysis.Analyzer) map[string]F {
+	facts := make(map[string]F)
+	for act := range graph.All() {
+		if act.Analyzer != a || act.Err != nil {
+			continue
+		}
+		for _, pf := range act.AllPackageFacts() {
+			if f, ok := pf.Fact.(F); ok {
+				facts[pf.Package.Path()] = f
+			}
+		}
+	}
+	return facts
+}
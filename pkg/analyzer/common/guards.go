@@ -0,0 +1,291 @@
+package common
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// GuardPattern is an opt-in guard clause idiom that IsErrGuard accepts in
+// addition to the error guard.
+type GuardPattern uint
+
+const (
+	// GuardCommaOK is `if !ok { return ... }`.
+	GuardCommaOK GuardPattern = 1 << iota
+	// GuardNilCheck is `if x == nil { return nil, ErrX }`.
+	GuardNilCheck
+	// GuardLogged is an error guard with one call before the return:
+	// `if err != nil { log.Print(err); return err }`.
+	GuardLogged
+	// GuardLoop is `if cond { continue }` or `if cond { break }` leaving a
+	// loop; see LoopGuards.
+	GuardLoop
+	// GuardPanic is an invariant check `if cond { panic(...) }`.
+	GuardPanic
+)
+
+var guardPatternNames = []struct {
+	pattern GuardPattern
+	name    string
+}{
+	{GuardCommaOK, "commaok"},
+	{GuardNilCheck, "nilcheck"},
+	{GuardLogged, "logged"},
+	{GuardLoop, "loop"},
+	{GuardPanic, "panic"},
+}
+
+// GuardPatterns is a set of enabled guard patterns. As a flag value it is a
+// comma-separated list of pattern names.
+type GuardPatterns GuardPattern
+
+// Guards holds the guard patterns enabled for every analyzer. Like
+// ExcludePatterns, the analyzers exempting guards register flags pointing to
+// this variable.
+var Guards GuardPatterns
+
+// RegisterGuardsFlag registers the shared -guards flag on an analyzer.
+func RegisterGuardsFlag(a *analysis.Analyzer) {
+	a.Flags.Var(&Guards, "guards",
+		"comma-separated guard patterns exempt like error guards: commaok, nilcheck, logged, loop, panic")
+}
+
+// Has reports whether pattern is enabled.
+func (g GuardPatterns) Has(pattern GuardPattern) bool {
+	return GuardPattern(g)&pattern != 0
+}
+
+// String returns the flag value for g.
+func (g GuardPatterns) String() string {
+	var names []string
+	for _, p := range guardPatternNames {
+		if g.Has(p.pattern) {
+			names = append(names, p.name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// Set parses a -guards flag value, replacing the enabled patterns.
+func (g *GuardPatterns) Set(value string) error {
+	var set GuardPatterns
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		pattern, ok := guardPatternByName(name)
+		if !ok {
+			return fmt.Errorf("invalid guard pattern %q (want commaok, nilcheck, logged, loop or panic)", name)
+		}
+		set |= GuardPatterns(pattern)
+	}
+	*g = set
+	return nil
+}
+
+func guardPatternByName(name string) (GuardPattern, bool) {
+	for _, p := range guardPatternNames {
+		if p.name == name {
+			return p.pattern, true
+		}
+	}
+	return 0, false
+}
+
+// guardMatchers pairs each guard pattern with the function recognizing it.
+var guardMatchers = []struct {
+	pattern GuardPattern
+	match   func(info *types.Info, ifStmt *ast.IfStmt) bool
+}{
+	{GuardCommaOK, isCommaOKGuard},
+	{GuardNilCheck, isNilCheckGuard},
+	{GuardLogged, isLoggedGuard},
+	{GuardPanic, isPanicGuard},
+}
+
+// isOptInGuard reports whether ifStmt, which has no else branch, matches one
+// of the enabled guard patterns.
+func isOptInGuard(info *types.Info, ifStmt *ast.IfStmt) bool {
+	for _, m := range guardMatchers {
+		if Guards.Has(m.pattern) && m.match(info, ifStmt) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCommaOKGuard matches `if !<ident> { return ... }`. With type
+// information the identifier must be a bool.
+func isCommaOKGuard(info *types.Info, ifStmt *ast.IfStmt) bool {
+	not, ok := ifStmt.Cond.(*ast.UnaryExpr)
+	if !ok || not.Op != token.NOT {
+		return false
+	}
+	ident, ok := not.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	if info != nil && !isBool(info.TypeOf(ident)) {
+		return false
+	}
+	return len(ifStmt.Body.List) == 1 && isReturn(ifStmt.Body.List[0])
+}
+
+// isNilCheckGuard matches `if <ident> == nil { return ..., ErrX }` where the
+// other results are zero values and the last is an error value other than
+// the checked identifier.
+func isNilCheckGuard(info *types.Info, ifStmt *ast.IfStmt) bool {
+	ident := identIsNil(ifStmt.Cond)
+	if ident == nil || len(ifStmt.Body.List) != 1 {
+		return false
+	}
+	ret, ok := ifStmt.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) == 0 {
+		return false
+	}
+	if last, ok := ret.Results[len(ret.Results)-1].(*ast.Ident); ok && last.Name == ident.Name {
+		return false
+	}
+	// The checked identifier is nil here; the error must be a sentinel, a
+	// call or a literal.
+	return isErrReturn(info, ret.Results, ident)
+}
+
+// isLoggedGuard matches an error guard whose return is preceded by one call
+// statement, typically logging or metrics.
+func isLoggedGuard(info *types.Info, ifStmt *ast.IfStmt) bool {
+	if len(ifStmt.Body.List) != 2 {
+		return false
+	}
+	stmt, ok := ifStmt.Body.List[0].(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	if _, ok := stmt.X.(*ast.CallExpr); !ok {
+		return false
+	}
+	guard := *ifStmt
+	guard.Body = &ast.BlockStmt{List: ifStmt.Body.List[1:]}
+	return isErrGuard(info, &guard)
+}
+
+// LoopGuards returns the loop guards `if cond { continue }` and
+// `if cond { break }` within node, labeled or not, when the loop guard
+// pattern is enabled. Only branches to a loop are guards: an unlabeled break
+// in a switch or select case ends the case, not the iteration.
+func LoopGuards(node ast.Node) map[*ast.IfStmt]bool {
+	guards := make(map[*ast.IfStmt]bool)
+	if !Guards.Has(GuardLoop) {
+		return guards
+	}
+	// enclosing holds the nodes enclosing the current one, outermost first.
+	var enclosing []ast.Node
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n == nil {
+			enclosing = enclosing[:len(enclosing)-1]
+			return true
+		}
+		if ifStmt, ok := n.(*ast.IfStmt); ok {
+			if branch := loopBranch(ifStmt); branch != nil && branchesToLoop(branch, enclosing) {
+				guards[ifStmt] = true
+			}
+		}
+		enclosing = append(enclosing, n)
+		return true
+	})
+	return guards
+}
+
+// loopBranch returns the continue or break statement that is the only
+// statement of ifStmt, which has no else branch, or nil.
+func loopBranch(ifStmt *ast.IfStmt) *ast.BranchStmt {
+	if ifStmt.Else != nil || len(ifStmt.Body.List) != 1 {
+		return nil
+	}
+	branch, ok := ifStmt.Body.List[0].(*ast.BranchStmt)
+	if !ok || (branch.Tok != token.CONTINUE && branch.Tok != token.BREAK) {
+		return nil
+	}
+	return branch
+}
+
+// branchesToLoop reports whether branch, inside the enclosing nodes
+// (outermost first), continues or breaks a for or range loop.
+func branchesToLoop(branch *ast.BranchStmt, enclosing []ast.Node) bool {
+	for i := len(enclosing) - 1; i >= 0; i-- {
+		switch s := enclosing[i].(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.LabeledStmt:
+			if branch.Label != nil && branch.Label.Name == s.Label.Name {
+				return isLoop(s.Stmt)
+			}
+		case *ast.ForStmt, *ast.RangeStmt:
+			if branch.Label == nil {
+				return true
+			}
+		case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			if branch.Label == nil && branch.Tok == token.BREAK {
+				return false
+			}
+		}
+	}
+	return false
+}
+
+func isLoop(stmt ast.Stmt) bool {
+	switch stmt.(type) {
+	case *ast.ForStmt, *ast.RangeStmt:
+		return true
+	}
+	return false
+}
+
+// isPanicGuard matches `if cond { panic(...) }`.
+func isPanicGuard(info *types.Info, ifStmt *ast.IfStmt) bool {
+	if len(ifStmt.Body.List) != 1 {
+		return false
+	}
+	stmt, ok := ifStmt.Body.List[0].(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := stmt.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok || fn.Name != "panic" {
+		return false
+	}
+	return info == nil || info.Uses[fn] == types.Universe.Lookup("panic")
+}
+
+// identIsNil checks if the condition is `<ident> == nil` (or `nil == <ident>`).
+// Returns the identifier, or nil if the condition doesn't match.
+func identIsNil(cond ast.Expr) *ast.Ident {
+	binExpr, ok := cond.(*ast.BinaryExpr)
+	if !ok || binExpr.Op != token.EQL {
+		return nil
+	}
+	return nilComparand(binExpr)
+}
+
+func isReturn(stmt ast.Stmt) bool {
+	_, ok := stmt.(*ast.ReturnStmt)
+	return ok
+}
+
+func isBool(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsBoolean != 0
+}
@@ -0,0 +1,185 @@
+package common
+
+import (
+	"go/ast"
+	"testing"
+)
+
+func TestGuardPatternsSet(t *testing.T) {
+	var g GuardPatterns
+	if err := g.Set("loop, commaok"); err != nil {
+		t.Fatal(err)
+	}
+	if !g.Has(GuardLoop) || !g.Has(GuardCommaOK) || g.Has(GuardPanic) {
+		t.Errorf("Set(\"loop, commaok\") = %q", g.String())
+	}
+	if got, want := g.String(), "commaok,loop"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if err := g.Set(""); err != nil || g != 0 {
+		t.Errorf("Set(\"\") = %q, %v; want empty set", g.String(), err)
+	}
+	if err := g.Set("commaok,unless"); err == nil {
+		t.Error("Set with an unknown pattern: want error")
+	}
+}
+
+func TestIsErrGuardPatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern GuardPattern
+		code    string
+		want    bool
+	}{
+		{
+			name:    "comma-ok",
+			pattern: GuardCommaOK,
+			code:    `if !ok { return nil, ErrMissing }`,
+			want:    true,
+		},
+		{
+			name:    "comma-ok bare return",
+			pattern: GuardCommaOK,
+			code:    `if !ok { return }`,
+			want:    true,
+		},
+		{
+			name:    "comma-ok with else",
+			pattern: GuardCommaOK,
+			code:    `if !ok { return } else { x++ }`,
+			want:    false,
+		},
+		{
+			name:    "nil check",
+			pattern: GuardNilCheck,
+			code:    `if x == nil { return nil, errors.New("no x") }`,
+			want:    true,
+		},
+		{
+			name:    "nil check returning the nil value",
+			pattern: GuardNilCheck,
+			code:    `if x == nil { return nil, x }`,
+			want:    false,
+		},
+		{
+			name:    "logged",
+			pattern: GuardLogged,
+			code:    `if err != nil { log.Print(err); return nil, err }`,
+			want:    true,
+		},
+		{
+			name:    "logged with assignment",
+			pattern: GuardLogged,
+			code:    `if err != nil { n = 0; return nil, err }`,
+			want:    false,
+		},
+		{
+			name:    "panic",
+			pattern: GuardPanic,
+			code:    `if n < 0 { panic("negative") }`,
+			want:    true,
+		},
+		{
+			name:    "panic after a statement",
+			pattern: GuardPanic,
+			code:    `if n < 0 { n = 0; panic("negative") }`,
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ifStmt := parseIfStmt(t, tt.code)
+
+			Guards = 0
+			if IsErrGuard(nil, ifStmt) {
+				t.Errorf("IsErrGuard() with no patterns enabled = true, want false")
+			}
+
+			Guards = GuardPatterns(tt.pattern)
+			t.Cleanup(func() { Guards = 0 })
+			if got := IsErrGuard(nil, ifStmt); got != tt.want {
+				t.Errorf("IsErrGuard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoopGuards(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{
+			name: "continue",
+			code: `for _, x := range xs { if x == "" { continue } }`,
+			want: true,
+		},
+		{
+			name: "break",
+			code: `for { if done { break } }`,
+			want: true,
+		},
+		{
+			name: "break in a switch case",
+			code: `for { switch { case ready: if done { break } } }`,
+			want: false,
+		},
+		{
+			name: "break in a select case",
+			code: `for { select { case <-ch: if done { break } } }`,
+			want: false,
+		},
+		{
+			name: "continue in a switch case",
+			code: `for { switch { case ready: if done { continue } } }`,
+			want: true,
+		},
+		{
+			name: "labeled break in a switch case",
+			code: `outer: for { switch { case ready: if done { break outer } } }`,
+			want: true,
+		},
+		{
+			name: "labeled break of a switch",
+			code: `for { sw: switch { case ready: if done { break sw } } }`,
+			want: false,
+		},
+		{
+			name: "break in a type switch case",
+			code: `for { switch v.(type) { case int: if done { break } } }`,
+			want: false,
+		},
+		{
+			name: "with else",
+			code: `for { if done { break } else { n++ } }`,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := parseFuncDecl(t, "func f() {\n"+tt.code+"\n}")
+
+			Guards = 0
+			if got := LoopGuards(fn); len(got) != 0 {
+				t.Errorf("LoopGuards() with no patterns enabled = %d guards, want none", len(got))
+			}
+
+			Guards = GuardPatterns(GuardLoop)
+			t.Cleanup(func() { Guards = 0 })
+			guards := LoopGuards(fn)
+			got := false
+			ast.Inspect(fn, func(n ast.Node) bool {
+				if ifStmt, ok := n.(*ast.IfStmt); ok && guards[ifStmt] {
+					got = true
+				}
+				return true
+			})
+			if got != tt.want {
+				t.Errorf("LoopGuards() found a guard = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package common
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Iterators enables iterator-aware counting in nestdepth and cyclo: function
+// literals of the canonical iterator shape (see IteratorYield) add no nesting
+// level, and their `if !yield(...) { return }` exits are exempt like error
+// guards. Like ExcludePatterns, both analyzers register a flag pointing to
+// this variable.
+var Iterators bool
+
+// RegisterIteratorFlag registers the shared -iterators flag on an analyzer.
+func RegisterIteratorFlag(a *analysis.Analyzer) {
+	a.Flags.BoolVar(&Iterators, "iterators", false,
+		"do not charge iterator literals (func(yield func(T) bool)) a nesting level or their if !yield(...) { return } exits")
+}
+
+// IteratorYield reports whether lit has the canonical shape of a
+// range-over-func iterator, the underlying type of iter.Seq and iter.Seq2:
+// no results and a single parameter of type func(T) bool or func(K, V) bool.
+// It also returns the yield parameter's name, which is nil when the
+// parameter is unnamed.
+func IteratorYield(lit *ast.FuncLit) (*ast.Ident, bool) {
+	ft := lit.Type
+	if ft.Results != nil && len(ft.Results.List) > 0 {
+		return nil, false
+	}
+	if ft.Params == nil || len(ft.Params.List) != 1 || len(ft.Params.List[0].Names) > 1 {
+		return nil, false
+	}
+	param := ft.Params.List[0]
+	yield, ok := param.Type.(*ast.FuncType)
+	if !ok || !returnsBool(yield) {
+		return nil, false
+	}
+	if n := fieldCount(yield.Params); n < 1 || n > 2 {
+		return nil, false
+	}
+	if len(param.Names) == 0 {
+		return nil, true
+	}
+	return param.Names[0], true
+}
+
+// returnsBool reports whether ft has the single result bool.
+func returnsBool(ft *ast.FuncType) bool {
+	if ft.Results == nil || fieldCount(ft.Results) != 1 {
+		return false
+	}
+	ident, ok := ft.Results.List[0].Type.(*ast.Ident)
+	return ok && ident.Name == "bool"
+}
+
+// fieldCount counts the entries of a field list, expanding grouped names.
+func fieldCount(fl *ast.FieldList) int {
+	if fl == nil {
+		return 0
+	}
+	n := 0
+	for _, field := range fl.List {
+		n += max(len(field.Names), 1)
+	}
+	return n
+}
+
+// YieldGuards returns the early exits `if !yield(...) { return }` in the
+// iterator literals within node, where yield is the literal's own yield
+// parameter. Like error guards, they are boilerplate every iterator needs.
+func YieldGuards(node ast.Node) map[*ast.IfStmt]bool {
+	guards := make(map[*ast.IfStmt]bool)
+	ast.Inspect(node, func(n ast.Node) bool {
+		lit, ok := n.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		yield, ok := IteratorYield(lit)
+		if !ok || yield == nil {
+			return true
+		}
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			if ifStmt, ok := n.(*ast.IfStmt); ok && isYieldGuard(ifStmt, yield.Name) {
+				guards[ifStmt] = true
+			}
+			return true
+		})
+		return true
+	})
+	return guards
+}
+
+// isYieldGuard reports whether ifStmt is `if !yield(...) { return }` with no
+// init statement and no else branch.
+func isYieldGuard(ifStmt *ast.IfStmt, yield string) bool {
+	if ifStmt.Init != nil || ifStmt.Else != nil || len(ifStmt.Body.List) != 1 {
+		return false
+	}
+	ret, ok := ifStmt.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 0 {
+		return false
+	}
+	not, ok := ifStmt.Cond.(*ast.UnaryExpr)
+	if !ok || not.Op != token.NOT {
+		return false
+	}
+	call, ok := ast.Unparen(not.X).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := call.Fun.(*ast.Ident)
+	return ok && fn.Name == yield
+}
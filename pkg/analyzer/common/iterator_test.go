@@ -0,0 +1,101 @@
+package common
+
+import (
+	"go/ast"
+	"go/parser"
+	"testing"
+)
+
+func TestIteratorYield(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		wantOK    bool
+		wantYield string
+	}{
+		{
+			name:      "iter.Seq shape",
+			src:       "func(yield func(int) bool) {}",
+			wantOK:    true,
+			wantYield: "yield",
+		},
+		{
+			name:      "iter.Seq2 shape",
+			src:       "func(emit func(string, error) bool) {}",
+			wantOK:    true,
+			wantYield: "emit",
+		},
+		{
+			name:   "unnamed yield",
+			src:    "func(func(int) bool) {}",
+			wantOK: true,
+		},
+		{
+			name: "results",
+			src:  "func(yield func(int) bool) error { return nil }",
+		},
+		{
+			name: "callback without bool result",
+			src:  "func(visit func(int)) {}",
+		},
+		{
+			name: "three values",
+			src:  "func(yield func(a, b, c int) bool) {}",
+		},
+		{
+			name: "two parameters",
+			src:  "func(yield func(int) bool, n int) {}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parser.ParseExpr(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			yield, ok := IteratorYield(expr.(*ast.FuncLit))
+			if ok != tt.wantOK {
+				t.Fatalf("IteratorYield() ok = %v, want %v", ok, tt.wantOK)
+			}
+			got := ""
+			if yield != nil {
+				got = yield.Name
+			}
+			if got != tt.wantYield {
+				t.Errorf("IteratorYield() yield = %q, want %q", got, tt.wantYield)
+			}
+		})
+	}
+}
+
+func TestYieldGuards(t *testing.T) {
+	src := `func(yield func(int) bool) {
+		if !yield(1) {
+			return
+		}
+		if !(yield(2)) {
+			return
+		}
+		if !yield(3) {
+			println()
+			return
+		}
+		if !ready(4) {
+			return
+		}
+	}`
+	expr, err := parser.ParseExpr(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lit := expr.(*ast.FuncLit)
+	guards := YieldGuards(lit)
+
+	want := []bool{true, true, false, false}
+	for i, stmt := range lit.Body.List {
+		if got := guards[stmt.(*ast.IfStmt)]; got != want[i] {
+			t.Errorf("statement %d: guard = %v, want %v", i, got, want[i])
+		}
+	}
+}
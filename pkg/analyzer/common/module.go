@@ -0,0 +1,28 @@
+package common
+
+import (
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// IsModulePackage reports whether pkgPath belongs to module. Drivers that do
+// not report a module (e.g. GOPATH mode) pass nil or an empty path, in which
+// case every non-stdlib package is treated as part of the module.
+func IsModulePackage(module *analysis.Module, pkgPath string) bool {
+	if module == nil || module.Path == "" {
+		return !IsStdlib(pkgPath)
+	}
+	return pkgPath == module.Path || strings.HasPrefix(pkgPath, module.Path+"/")
+}
+
+// IsMainModule reports whether the package pkgPath, analyzed with module,
+// belongs to a module under analysis rather than a dependency. Drivers pass
+// each package its own module, and only dependency modules have a version.
+// Without a module, every non-stdlib package counts, as in IsModulePackage.
+func IsMainModule(module *analysis.Module, pkgPath string) bool {
+	if module == nil || module.Path == "" {
+		return !IsStdlib(pkgPath)
+	}
+	return module.Version == ""
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestIsModulePackage(t *testing.T) {
+	mod := &analysis.Module{Path: "example.com/app"}
+
+	tests := []struct {
+		name    string
+		module  *analysis.Module
+		pkgPath string
+		want    bool
+	}{
+		{name: "module root", module: mod, pkgPath: "example.com/app", want: true},
+		{name: "module subpackage", module: mod, pkgPath: "example.com/app/internal/db", want: true},
+		{name: "shared prefix is not the module", module: mod, pkgPath: "example.com/application", want: false},
+		{name: "third party", module: mod, pkgPath: "ext.pkg/dep", want: false},
+		{name: "stdlib", module: mod, pkgPath: "fmt", want: false},
+		{name: "no module falls back to non-stdlib", module: nil, pkgPath: "ext.pkg/dep", want: true},
+		{name: "empty module path falls back to non-stdlib", module: &analysis.Module{}, pkgPath: "fmt", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsModulePackage(tc.module, tc.pkgPath); got != tc.want {
+				t.Fatalf("IsModulePackage(%q) = %v, want %v", tc.pkgPath, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsMainModule(t *testing.T) {
+	tests := []struct {
+		name    string
+		module  *analysis.Module
+		pkgPath string
+		want    bool
+	}{
+		{name: "main module", module: &analysis.Module{Path: "example.com/app"}, pkgPath: "example.com/app/db", want: true},
+		{name: "dependency", module: &analysis.Module{Path: "golang.org/x/tools", Version: "v0.32.0"}, pkgPath: "golang.org/x/tools/go/analysis", want: false},
+		{name: "no module falls back to non-stdlib", module: nil, pkgPath: "ext.pkg/dep", want: true},
+		{name: "stdlib", module: &analysis.Module{}, pkgPath: "fmt", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsMainModule(tc.module, tc.pkgPath); got != tc.want {
+				t.Fatalf("IsMainModule(%q) = %v, want %v", tc.pkgPath, got, tc.want)
+			}
+		})
+	}
+}
@@ -13,13 +13,32 @@ import (
 //
 // It returns modified thresholds if overrides are found, or the defaults if not.
 func ParseOverrides(funcDecl *ast.FuncDecl, metricName string, defaults Thresholds) Thresholds {
-	if funcDecl.Doc == nil {
-		return defaults
+	return ParseDocOverrides(metricName, defaults, funcDecl.Doc)
+}
+
+// ParseDocOverrides scans doc comment groups for override directives using the
+// same syntax as ParseOverrides. It serves declarations other than functions
+// (types, fields, variables). When several groups are given, the first group
+// containing a directive for metricName wins; nil groups are skipped.
+func ParseDocOverrides(metricName string, defaults Thresholds, docs ...*ast.CommentGroup) Thresholds {
+	for _, doc := range docs {
+		if result, ok := parseDirective(doc, metricName, defaults); ok {
+			return result
+		}
+	}
+	return defaults
+}
+
+// parseDirective returns the thresholds from the first directive for
+// metricName in doc, and whether such a directive was found.
+func parseDirective(doc *ast.CommentGroup, metricName string, defaults Thresholds) (Thresholds, bool) {
+	if doc == nil {
+		return defaults, false
 	}
 
 	prefix := "//complexity:" + metricName + ":"
 
-	for _, comment := range funcDecl.Doc.List {
+	for _, comment := range doc.List {
 		text := strings.TrimSpace(comment.Text)
 		if !strings.HasPrefix(text, prefix) {
 			continue
@@ -51,8 +70,8 @@ func ParseOverrides(funcDecl *ast.FuncDecl, metricName string, defaults Threshol
 			}
 		}
 
-		return result
+		return result, true
 	}
 
-	return defaults
+	return defaults, false
 }
@@ -92,3 +92,59 @@ func TestParseOverrides(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDocOverrides(t *testing.T) {
+	defaults := Thresholds{WarnAt: 4, FailAt: 6}
+
+	fset := token.NewFileSet()
+	src := `package p
+
+//complexity:typeexpr:warn=8,fail=10
+type T struct {
+	//complexity:typeexpr:fail=20
+	A map[string]int
+	B int
+}
+`
+	f, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decl := f.Decls[0].(*ast.GenDecl)
+	fields := decl.Specs[0].(*ast.TypeSpec).Type.(*ast.StructType).Fields.List
+
+	tests := []struct {
+		name string
+		docs []*ast.CommentGroup
+		want Thresholds
+	}{
+		{
+			name: "no groups",
+			want: defaults,
+		},
+		{
+			name: "decl doc",
+			docs: []*ast.CommentGroup{decl.Doc},
+			want: Thresholds{WarnAt: 8, FailAt: 10},
+		},
+		{
+			name: "first group with directive wins",
+			docs: []*ast.CommentGroup{fields[0].Doc, decl.Doc},
+			want: Thresholds{WarnAt: 4, FailAt: 20},
+		},
+		{
+			name: "nil group falls through",
+			docs: []*ast.CommentGroup{fields[1].Doc, decl.Doc},
+			want: Thresholds{WarnAt: 8, FailAt: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseDocOverrides("typeexpr", defaults, tt.docs...)
+			if got != tt.want {
+				t.Errorf("ParseDocOverrides() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package common
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// ResultGuards returns the error guards within node that return through
+// named results, which IsErrGuard cannot recognize without the enclosing
+// function's signature. In a function whose last result is a named error,
+// such as func f() (n int, err error), these are guards on an error
+// identifier whose single return (after one call, with the logged guard
+// pattern enabled) is either
+//
+//   - a bare return, when the checked identifier is the named error result:
+//     `if err != nil { return }`, or
+//   - a return of the named results themselves, or zero values, in place of
+//     the leading results: `if err != nil { return n, err }`.
+//
+// Each guard is resolved against its innermost enclosing function
+// declaration or literal. node is usually a declaration or literal; ifs
+// outside any function within node are never result guards. Type
+// information is required: with nil info the map is empty.
+func ResultGuards(info *types.Info, node ast.Node) map[*ast.IfStmt]bool {
+	f := &resultGuardFinder{info: info, guards: make(map[*ast.IfStmt]bool)}
+	if info != nil {
+		f.walk(node, nil)
+	}
+	return f.guards
+}
+
+// resultGuardFinder collects result guards, tracking the results of the
+// innermost enclosing function.
+type resultGuardFinder struct {
+	info   *types.Info
+	guards map[*ast.IfStmt]bool
+}
+
+// walk visits node, within a function with the given results (nil outside
+// any function).
+func (f *resultGuardFinder) walk(node ast.Node, results *types.Tuple) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncDecl:
+			if s.Body != nil {
+				f.walk(s.Body, funcDeclResults(f.info, s))
+			}
+			return false
+		case *ast.FuncLit:
+			f.walk(s.Body, funcLitResults(f.info, s))
+			return false
+		case *ast.IfStmt:
+			if namedErrResult(results) != nil && f.isResultGuard(results, s) {
+				f.guards[s] = true
+			}
+		}
+		return true
+	})
+}
+
+func funcDeclResults(info *types.Info, decl *ast.FuncDecl) *types.Tuple {
+	fn, ok := info.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return nil
+	}
+	return fn.Type().(*types.Signature).Results()
+}
+
+func funcLitResults(info *types.Info, lit *ast.FuncLit) *types.Tuple {
+	sig, ok := info.TypeOf(lit).(*types.Signature)
+	if !ok {
+		return nil
+	}
+	return sig.Results()
+}
+
+// namedErrResult returns the last result if it is named and implements
+// error, or nil.
+func namedErrResult(results *types.Tuple) *types.Var {
+	if results == nil || results.Len() == 0 {
+		return nil
+	}
+	last := results.At(results.Len() - 1)
+	if last.Name() == "" || last.Name() == "_" || !implementsError(last.Type()) {
+		return nil
+	}
+	return last
+}
+
+// isResultGuard matches an error guard returning through the named results
+// of a function with the given results, as described at ResultGuards.
+func (f *resultGuardFinder) isResultGuard(results *types.Tuple, ifStmt *ast.IfStmt) bool {
+	if ifStmt.Else != nil {
+		return false
+	}
+	errIdent := identNotNil(ifStmt.Cond)
+	if errIdent == nil || !implementsError(f.info.TypeOf(errIdent)) {
+		return false
+	}
+	ret, ok := guardReturn(ifStmt.Body.List)
+	return ok && f.returnsThroughResults(results, ret, errIdent)
+}
+
+// returnsThroughResults reports whether ret returns errIdent's error through
+// the named results: bare, when errIdent is the named error result, or with
+// each leading result the named result itself or a zero value.
+func (f *resultGuardFinder) returnsThroughResults(results *types.Tuple, ret *ast.ReturnStmt, errIdent *ast.Ident) bool {
+	if len(ret.Results) == 0 {
+		return f.info.ObjectOf(errIdent) == namedErrResult(results)
+	}
+	if len(ret.Results) != results.Len() {
+		return false
+	}
+	last := len(ret.Results) - 1
+	for i, expr := range ret.Results[:last] {
+		ident, isIdent := expr.(*ast.Ident)
+		if !isZeroValue(expr) && (!isIdent || f.info.Uses[ident] != results.At(i)) {
+			return false
+		}
+	}
+	return isErrValue(f.info, ret.Results[last], errIdent)
+}
+
+// guardReturn returns the return statement of a guard body: its single
+// statement, or the second of two when the logged guard pattern is enabled
+// and the first is a call.
+func guardReturn(body []ast.Stmt) (*ast.ReturnStmt, bool) {
+	if len(body) == 2 && Guards.Has(GuardLogged) {
+		stmt, ok := body[0].(*ast.ExprStmt)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := stmt.X.(*ast.CallExpr); !ok {
+			return nil, false
+		}
+		body = body[1:]
+	}
+	if len(body) != 1 {
+		return nil, false
+	}
+	ret, ok := body[0].(*ast.ReturnStmt)
+	return ret, ok
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestResultGuards(t *testing.T) {
+	tests := []struct {
+		name    string
+		results string
+		code    string
+		want    bool
+	}{
+		{
+			name:    "bare return",
+			results: "(n int, err error)",
+			code:    `if err != nil { return }`,
+			want:    true,
+		},
+		{
+			name:    "named results returned",
+			results: "(n int, err error)",
+			code:    `if err != nil { return n, err }`,
+			want:    true,
+		},
+		{
+			name:    "named results and a wrapped error",
+			results: "(n int, err error)",
+			code:    `if err != nil { return n, wrap(err) }`,
+			want:    true,
+		},
+		{
+			name:    "zero value and a sentinel",
+			results: "(n int, err error)",
+			code:    `if other != nil { return 0, ErrBad }`,
+			want:    true,
+		},
+		{
+			name:    "bare return checking another error",
+			results: "(n int, err error)",
+			code:    `if other != nil { return }`,
+			want:    false,
+		},
+		{
+			name:    "changed result",
+			results: "(n int, err error)",
+			code:    `if err != nil { return n + 1, err }`,
+			want:    false,
+		},
+		{
+			name:    "local in place of a named result",
+			results: "(n int, err error)",
+			code:    `if err != nil { return m, err }`,
+			want:    false,
+		},
+		{
+			name:    "unnamed results",
+			results: "(int, error)",
+			code:    `if other != nil { return m, other }`,
+			want:    false,
+		},
+		{
+			name:    "named result that is not an error",
+			results: "(n int, p *int)",
+			code:    `if other != nil { return }`,
+			want:    false,
+		},
+		{
+			name:    "else branch",
+			results: "(n int, err error)",
+			code:    `if err != nil { return } else { n++ }`,
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := `package p
+type badErr struct{}
+func (*badErr) Error() string { return "bad" }
+var ErrBad error = &badErr{}
+func wrap(err error) error { return err }
+func f(other error, m int) ` + tt.results + ` {
+` + tt.code + `
+	panic(0)
+}`
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "test.go", src, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			info := &types.Info{
+				Types: make(map[ast.Expr]types.TypeAndValue),
+				Defs:  make(map[*ast.Ident]types.Object),
+				Uses:  make(map[*ast.Ident]types.Object),
+			}
+			if _, err := new(types.Config).Check("p", fset, []*ast.File{f}, info); err != nil {
+				t.Fatal(err)
+			}
+
+			var ifStmt *ast.IfStmt
+			ast.Inspect(f, func(n ast.Node) bool {
+				if is, ok := n.(*ast.IfStmt); ok && ifStmt == nil {
+					ifStmt = is
+				}
+				return ifStmt == nil
+			})
+
+			if got := ResultGuards(info, f)[ifStmt]; got != tt.want {
+				t.Errorf("ResultGuards()[if] = %v, want %v", got, tt.want)
+			}
+			if ResultGuards(nil, f)[ifStmt] {
+				t.Error("ResultGuards() with nil info found a guard")
+			}
+		})
+	}
+}
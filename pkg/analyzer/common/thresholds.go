@@ -22,6 +22,15 @@ type Thresholds struct {
 	FailAt int
 }
 
+// Measure is a metric value together with the thresholds it was classified
+// against (after overrides) and the resulting zone. Per-function analyzers
+// return their measures as results so that other analyzers can build on them.
+type Measure struct {
+	Value      int
+	Thresholds Thresholds
+	Zone       Zone
+}
+
 // Validate returns an error if the thresholds are invalid.
 // Both values must be non-negative and WarnAt must not exceed FailAt.
 func (t Thresholds) Validate(name string) error {
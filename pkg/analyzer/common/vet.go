@@ -1,17 +1,30 @@
 package common
 
-import "golang.org/x/tools/go/analysis"
+import (
+	"flag"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
 
 // ConfigureRedZoneOnly sets each analyzer's warn threshold to its fail
 // threshold so only red-zone violations are reported. go vet invokes the
 // tool through unitchecker, which has no -warnings flag; red-zone-only
 // is the vet default. Explicit -metric.warn flags still override.
+//
+// Analyzers that gate more than one value pair their extra thresholds as
+// "<name>-warn" and "<name>-fail"; each such pair is aligned the same way.
 func ConfigureRedZoneOnly(analyzers []*analysis.Analyzer) {
 	for _, a := range analyzers {
-		fail := a.Flags.Lookup("fail")
-		if fail == nil {
-			continue
-		}
-		_ = a.Flags.Set("warn", fail.Value.String())
+		a.Flags.VisitAll(func(f *flag.Flag) {
+			if f.Name != "fail" && !strings.HasSuffix(f.Name, "-fail") {
+				return
+			}
+			warn := strings.TrimSuffix(f.Name, "fail") + "warn"
+			if a.Flags.Lookup(warn) == nil {
+				return
+			}
+			_ = a.Flags.Set(warn, f.Value.String())
+		})
 	}
 }
@@ -24,3 +24,23 @@ func TestConfigureRedZoneOnly(t *testing.T) {
 		t.Fatalf("failAt = %d, want 7", failAt)
 	}
 }
+
+func TestConfigureRedZoneOnlyNamedPairs(t *testing.T) {
+	var warnAt, failAt, sizeWarnAt, sizeFailAt int
+
+	analyzer := &analysis.Analyzer{Name: "testmetric"}
+	analyzer.Flags.Init("testmetric", flag.ExitOnError)
+	analyzer.Flags.IntVar(&warnAt, "warn", 5, "warning threshold")
+	analyzer.Flags.IntVar(&failAt, "fail", 7, "failure threshold")
+	analyzer.Flags.IntVar(&sizeWarnAt, "size-warn", 10, "size warning threshold")
+	analyzer.Flags.IntVar(&sizeFailAt, "size-fail", 15, "size failure threshold")
+
+	ConfigureRedZoneOnly([]*analysis.Analyzer{analyzer})
+
+	if warnAt != 7 {
+		t.Fatalf("warnAt = %d, want 7", warnAt)
+	}
+	if sizeWarnAt != 15 {
+		t.Fatalf("sizeWarnAt = %d, want 15", sizeWarnAt)
+	}
+}
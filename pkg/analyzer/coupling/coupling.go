@@ -0,0 +1,208 @@
+package coupling
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "coupling",
+	Doc: "reports packages with high coupling or far from the main sequence\n\n" +
+		"Computes Robert Martin's package metrics over module-internal imports: " +
+		"efferent coupling Ce (packages this package imports), afferent coupling " +
+		"Ca (packages importing it), instability I = Ce/(Ca+Ce), abstractness A " +
+		"(interfaces / all named types) and distance from the main sequence " +
+		"D = |A + I - 1|. Ce is reported by every driver. Ca, I and D need the " +
+		"whole import graph and are reported by the standalone command only.",
+	Run:        run,
+	FactTypes:  []analysis.Fact{new(Fact)},
+	ResultType: reflect.TypeOf((*Result)(nil)),
+}
+
+var (
+	warnAt         int
+	failAt         int
+	efferentWarnAt int
+	efferentFailAt int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 70,
+		"distance from the main sequence (percent) at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 90,
+		"distance from the main sequence (percent) at or above this triggers a failure (red zone)")
+	Analyzer.Flags.IntVar(&efferentWarnAt, "efferent-warn", 10,
+		"efferent coupling at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&efferentFailAt, "efferent-fail", 15,
+		"efferent coupling at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+// Fact is exported for every package. It carries what dependents need to
+// compute afferent coupling and abstractness without reloading the package.
+type Fact struct {
+	Imports  []string // module-internal import paths, sorted
+	Abstract int      // package-level interface types
+	Concrete int      // package-level non-interface named types
+}
+
+func (*Fact) AFact() {}
+
+func (f *Fact) String() string {
+	return fmt.Sprintf("coupling(Ce=%d, abstract=%d, concrete=%d)", len(f.Imports), f.Abstract, f.Concrete)
+}
+
+// Result is the coupling analyzer's per-package result, consumed by
+// ReportModule once every package has been analyzed.
+type Result struct {
+	Path string
+	Fact *Fact
+	Pos  token.Pos           // package clause the diagnostics are reported at
+	Skip bool                // excluded by -exclude
+	Docs []*ast.CommentGroup // package doc comments, searched for overrides
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if err := (common.Thresholds{WarnAt: warnAt, FailAt: failAt}).Validate("coupling"); err != nil {
+		return nil, err
+	}
+	efferentDefaults := common.Thresholds{WarnAt: efferentWarnAt, FailAt: efferentFailAt}
+	if err := efferentDefaults.Validate("coupling-efferent"); err != nil {
+		return nil, err
+	}
+
+	fact := &Fact{}
+	for _, imp := range pass.Pkg.Imports() {
+		if common.IsModulePackage(pass.Module, imp.Path()) {
+			fact.Imports = append(fact.Imports, imp.Path())
+		}
+	}
+	slices.Sort(fact.Imports)
+
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+		if types.IsInterface(tn.Type()) {
+			fact.Abstract++
+		} else {
+			fact.Concrete++
+		}
+	}
+	pass.ExportPackageFact(fact)
+
+	result := &Result{Path: pass.Pkg.Path(), Fact: fact}
+	if len(pass.Files) == 0 {
+		result.Skip = true
+		return result, nil
+	}
+	result.Pos = pass.Files[0].Name.Pos()
+	result.Skip = common.IsExcluded(pass.Fset.Position(result.Pos).Filename)
+	for _, f := range pass.Files {
+		result.Docs = append(result.Docs, f.Doc)
+	}
+	if result.Skip {
+		return result, nil
+	}
+
+	thresholds := common.ParseDocOverrides("coupling-efferent", efferentDefaults, result.Docs...)
+	if zone := thresholds.Classify(len(fact.Imports)); zone != common.ZoneGreen {
+		pass.Report(analysis.Diagnostic{
+			Pos:      result.Pos,
+			Category: zone.Category(),
+			Message: fmt.Sprintf(
+				"package %s has efferent coupling of %d (warn: >=%d, fail: >=%d) [%s] "+
+					"(reduce by moving code that needs the extra packages into a separate package, or by depending on interfaces declared here)",
+				pass.Pkg.Name(), len(fact.Imports), thresholds.WarnAt, thresholds.FailAt,
+				zone.Category()),
+		})
+	}
+
+	return result, nil
+}
+
+// Metrics are the package metrics derived from the whole import graph.
+type Metrics struct {
+	Ca, Ce       int
+	Instability  float64
+	Abstractness float64
+	Distance     float64
+}
+
+// ReportModule computes afferent coupling, instability, abstractness and
+// distance from the main sequence for each root package in graph, and appends
+// diagnostics for packages outside the green zone to the coupling action of
+// that package. Packages known only through facts (non-root dependencies)
+// contribute to afferent coupling but are not reported. It is a no-op when
+// the coupling analyzer is not part of graph.
+func ReportModule(graph *checker.Graph) {
+	facts := common.PackageFacts[*Fact](graph, Analyzer)
+	afferent := make(map[string]int)
+	for _, f := range facts {
+		for _, imp := range f.Imports {
+			afferent[imp]++
+		}
+	}
+
+	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	for _, act := range common.RootActions(graph, Analyzer) {
+		result := act.Result.(*Result)
+		if result.Skip {
+			continue
+		}
+		m, ok := computeMetrics(result.Fact, afferent[result.Path])
+		if !ok {
+			continue
+		}
+		thresholds := common.ParseDocOverrides("coupling", defaults, result.Docs...)
+		zone := thresholds.Classify(int(math.Round(m.Distance * 100)))
+		if zone == common.ZoneGreen {
+			continue
+		}
+		act.Diagnostics = append(act.Diagnostics, analysis.Diagnostic{
+			Pos:      result.Pos,
+			Category: zone.Category(),
+			Message: fmt.Sprintf(
+				"package %s has distance from the main sequence of %.2f (warn: >=%.2f, fail: >=%.2f) [%s] "+
+					"(Ca=%d, Ce=%d, I=%.2f, A=%.2f; %s)",
+				act.Package.Types.Name(), m.Distance,
+				float64(thresholds.WarnAt)/100, float64(thresholds.FailAt)/100, zone.Category(),
+				m.Ca, m.Ce, m.Instability, m.Abstractness, distanceAdvice(m)),
+		})
+	}
+}
+
+// computeMetrics derives the package metrics from its fact and afferent
+// coupling. It reports false for packages with no module-internal coupling
+// in either direction, whose instability is undefined.
+func computeMetrics(f *Fact, ca int) (Metrics, bool) {
+	m := Metrics{Ca: ca, Ce: len(f.Imports)}
+	if m.Ca+m.Ce == 0 {
+		return m, false
+	}
+	m.Instability = float64(m.Ce) / float64(m.Ca+m.Ce)
+	if total := f.Abstract + f.Concrete; total > 0 {
+		m.Abstractness = float64(f.Abstract) / float64(total)
+	}
+	m.Distance = math.Abs(m.Abstractness + m.Instability - 1)
+	return m, true
+}
+
+func distanceAdvice(m Metrics) string {
+	if m.Abstractness+m.Instability < 1 {
+		return "zone of pain: many packages depend on these concrete types; introduce interfaces or split out the stable parts"
+	}
+	return "zone of uselessness: abstractions that few packages depend on; inline them into their users or remove them"
+}
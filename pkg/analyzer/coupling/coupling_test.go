@@ -0,0 +1,65 @@
+package coupling_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestCoupling(t *testing.T) {
+	testutil.SetFlag(t, coupling.Analyzer, "efferent-warn", "2")
+	testutil.SetFlag(t, coupling.Analyzer, "efferent-fail", "3")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, coupling.Analyzer, "coupling")
+}
+
+func TestReportModule(t *testing.T) {
+	// Loaded as by the standalone command.
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax | packages.NeedModule, Dir: "testdata/module"}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		t.Fatalf("%d package errors", n)
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{coupling.Analyzer}, pkgs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coupling.ReportModule(graph)
+
+	var got []string
+	for act := range graph.All() {
+		if !act.IsRoot {
+			continue
+		}
+		for _, d := range act.Diagnostics {
+			got = append(got, act.Package.Types.Name()+": "+d.Category+": "+d.Message)
+		}
+	}
+	sort.Strings(got)
+
+	want := []string{
+		"model: error: package model has distance from the main sequence of 1.00 (warn: >=0.70, fail: >=0.90) [error] " +
+			"(Ca=4, Ce=0, I=0.00, A=0.00; zone of pain: many packages depend on these concrete types; introduce interfaces or split out the stable parts)",
+		"plugins: error: package plugins has distance from the main sequence of 1.00 (warn: >=0.70, fail: >=0.90) [error] " +
+			"(Ca=0, Ce=1, I=1.00, A=1.00; zone of uselessness: abstractions that few packages depend on; inline them into their users or remove them)",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("diagnostics = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("diagnostic %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
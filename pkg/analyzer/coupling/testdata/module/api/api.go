@@ -0,0 +1,8 @@
+// Package api is abstract and stable: Ca=2, Ce=1, A=1. Distance 0.33.
+package api
+
+import "github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling/testdata/module/model"
+
+type UserStore interface {
+	Get(id int) model.User
+}
@@ -0,0 +1,16 @@
+// Package app is concrete and unstable: Ca=0, Ce=4, A=0. Distance 0.
+package app
+
+import (
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling/testdata/module/api"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling/testdata/module/model"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling/testdata/module/store"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling/testdata/module/units"
+)
+
+type App struct {
+	users api.UserStore
+	order model.Order
+	db    store.DB
+	unit  units.Meter
+}
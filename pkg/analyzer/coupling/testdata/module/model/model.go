@@ -0,0 +1,6 @@
+// Package model holds concrete types everyone depends on: Ca=4, Ce=0, A=0.
+// Distance 1.00 puts it in the zone of pain.
+package model
+
+type User struct{ Name string }
+type Order struct{ ID int }
@@ -0,0 +1,9 @@
+// Package plugins is abstract but nothing depends on it: Ca=0, Ce=1, A=1.
+// Distance 1.00 puts it in the zone of uselessness.
+package plugins
+
+import "github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling/testdata/module/model"
+
+type Plugin interface {
+	Apply(u model.User)
+}
@@ -0,0 +1,13 @@
+// Package store is concrete: Ca=1, Ce=2, A=0. Distance 0.33.
+package store
+
+import (
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling/testdata/module/api"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling/testdata/module/model"
+)
+
+type DB struct{}
+
+func (DB) Get(id int) model.User { return model.User{} }
+
+var _ api.UserStore = DB{}
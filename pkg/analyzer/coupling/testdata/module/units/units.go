@@ -0,0 +1,7 @@
+// Package units holds value types: Ca=1, Ce=0, A=0. Distance 1.00, but
+// the override accepts it.
+//
+//complexity:coupling:warn=101,fail=101 Leaf value types are meant to be stable and concrete.
+package units
+
+type Meter float64
@@ -0,0 +1,6 @@
+package api
+
+// Handler is abstract.
+type Handler interface {
+	Handle()
+}
@@ -0,0 +1,25 @@
+package coupling // want "package coupling has efferent coupling of 2 \\(warn: >=2, fail: >=3\\) \\[warning\\] \\(reduce by moving code that needs the extra packages into a separate package, or by depending on interfaces declared here\\)" package:"coupling\\(Ce=2, abstract=1, concrete=2\\)"
+
+import (
+	"fmt"
+
+	"coupling/api"
+	"coupling/store"
+)
+
+// Service is abstract.
+type Service interface {
+	Run() error
+}
+
+// Impl and Config are concrete.
+type Impl struct{ s store.Store }
+type Config struct{}
+
+// Alias is not counted.
+type Alias = Impl
+
+func (i Impl) Run() error {
+	var _ api.Handler
+	return fmt.Errorf("not implemented")
+}
@@ -0,0 +1,8 @@
+package store
+
+import "coupling/api"
+
+// Store is concrete.
+type Store struct {
+	h api.Handler
+}
@@ -0,0 +1,14 @@
+// Package app imports model and two third-party packages: Ce=1.
+package app
+
+import (
+	"example.com/app/model"
+	"example.org/dep/log"
+	"example.org/dep/plug"
+)
+
+func Run() {
+	u := model.User{Name: "app"}
+	plug.Register(u)
+	log.Print(u.Name)
+}
@@ -0,0 +1,8 @@
+// Package plug is a third-party plugin built against the module's model.
+// Its facts are computed against its own module, so it adds nothing to
+// model's afferent coupling.
+package plug
+
+import "example.com/app/model"
+
+func Register(model.User) {}
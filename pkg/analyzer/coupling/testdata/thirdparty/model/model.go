@@ -0,0 +1,5 @@
+// Package model is concrete and imported by app and by the third-party plug,
+// but only app is a module importer: Ca=1, Ce=0, A=0. Distance 1.00.
+package model
+
+type User struct{ Name string }
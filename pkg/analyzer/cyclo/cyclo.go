@@ -3,6 +3,8 @@ package cyclo
 import (
 	"fmt"
 	"go/ast"
+	"go/types"
+	"reflect"
 
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
 	"golang.org/x/tools/go/analysis"
@@ -16,8 +18,9 @@ var Analyzer = &analysis.Analyzer{
 		"Cyclomatic complexity is 1 + 1 for each branching/looping decision " +
 		"(if, for, range, case). Else clauses, default, and boolean operators " +
 		"do not count. Error guard clauses are exempt.",
-	Run:      run,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:        run,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	ResultType: reflect.TypeOf(Result(nil)),
 }
 
 var (
@@ -32,8 +35,16 @@ func init() {
 		"cyclomatic complexity at or above this triggers a failure (red zone)")
 	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
 		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+	common.RegisterFuncLitFlags(Analyzer)
+	common.RegisterIteratorFlag(Analyzer)
+	common.RegisterGuardsFlag(Analyzer)
 }
 
+// Result maps each analyzed function to its measure. Functions without a
+// body and functions in excluded files are absent, and so are function
+// literals measured as units of their own.
+type Result map[*ast.FuncDecl]common.Measure
+
 func run(pass *analysis.Pass) (any, error) {
 	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
@@ -41,6 +52,7 @@ func run(pass *analysis.Pass) (any, error) {
 		return nil, err
 	}
 
+	result := make(Result)
 	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
 
 	insp.Preorder(nodeFilter, func(n ast.Node) {
@@ -52,18 +64,26 @@ func run(pass *analysis.Pass) (any, error) {
 			return
 		}
 
-		funcName := common.FuncName(funcDecl)
 		thresholds := common.ParseOverrides(funcDecl, "cyclo", defaults)
+		complexity := calcComplexity(pass.TypesInfo, funcDecl, funcDecl.Body)
+		result[funcDecl] = check(pass, funcDecl, common.FuncName(funcDecl), complexity, thresholds)
+	})
 
-		complexity := calcComplexity(funcDecl.Body)
-		zone := thresholds.Classify(complexity)
+	for _, unit := range common.PassFuncLitUnits(pass) {
+		thresholds := common.ParseDocOverrides("cyclo", defaults, unit.Docs...)
+		check(pass, unit.Lit, unit.Name, calcComplexity(pass.TypesInfo, unit.Lit, unit.Lit.Body), thresholds)
+	}
 
-		if zone == common.ZoneGreen {
-			return
-		}
+	return result, nil
+}
 
+// check classifies a function's cyclomatic complexity and reports it outside the
+// green zone.
+func check(pass *analysis.Pass, node ast.Node, funcName string, complexity int, thresholds common.Thresholds) common.Measure {
+	zone := thresholds.Classify(complexity)
+	if zone != common.ZoneGreen {
 		pass.Report(analysis.Diagnostic{
-			Pos:      funcDecl.Pos(),
+			Pos:      node.Pos(),
 			Category: zone.Category(),
 			Message: fmt.Sprintf(
 				"function %s has cyclomatic complexity of %d (warn: >=%d, fail: >=%d) [%s] "+
@@ -71,21 +91,30 @@ func run(pass *analysis.Pass) (any, error) {
 				funcName, complexity, thresholds.WarnAt, thresholds.FailAt,
 				zone.Category()),
 		})
-	})
-
-	return nil, nil
+	}
+	return common.Measure{Value: complexity, Thresholds: thresholds, Zone: zone}
 }
 
 // calcComplexity computes the cyclomatic complexity of a function body.
 // Base complexity is 1. Each branching/looping decision adds 1.
-func calcComplexity(body *ast.BlockStmt) int {
+// fn is the declaration or literal owning body.
+func calcComplexity(info *types.Info, fn ast.Node, body *ast.BlockStmt) int {
 	complexity := 1
+	resultGuards := common.ResultGuards(info, fn)
+	loopGuards := common.LoopGuards(fn)
+	var yieldGuards map[*ast.IfStmt]bool
+	if common.Iterators {
+		yieldGuards = common.YieldGuards(fn)
+	}
 
 	ast.Inspect(body, func(n ast.Node) bool {
 		switch s := n.(type) {
+		case *ast.FuncLit:
+			// Detached literals are measured as units of their own.
+			return !common.FuncLitsDetached()
 		case *ast.IfStmt:
-			// Error guard clauses are exempt.
-			if common.IsErrGuard(s) {
+			// Guard clauses and iterator yield exits are exempt.
+			if common.IsErrGuard(info, s) || resultGuards[s] || loopGuards[s] || yieldGuards[s] {
 				return false
 			}
 			complexity++
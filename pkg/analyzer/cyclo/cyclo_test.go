@@ -3,6 +3,7 @@ package cyclo_test
 import (
 	"testing"
 
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/cyclo"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
@@ -11,3 +12,41 @@ func TestCyclo(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, cyclo.Analyzer, "cyclo")
 }
+
+func TestFuncLits(t *testing.T) {
+	testutil.SetFlag(t, cyclo.Analyzer, "warn", "3")
+	testutil.SetFlag(t, cyclo.Analyzer, "fail", "5")
+	testutil.SetFlag(t, cyclo.Analyzer, "funclits", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, cyclo.Analyzer, "funclits")
+}
+
+func TestFuncLitsDetached(t *testing.T) {
+	testutil.SetFlag(t, cyclo.Analyzer, "warn", "3")
+	testutil.SetFlag(t, cyclo.Analyzer, "fail", "5")
+	testutil.SetFlag(t, cyclo.Analyzer, "funclits", "true")
+	testutil.SetFlag(t, cyclo.Analyzer, "funclits-detach", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, cyclo.Analyzer, "detached")
+}
+
+func TestIterators(t *testing.T) {
+	testutil.SetFlag(t, cyclo.Analyzer, "iterators", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, cyclo.Analyzer, "iterators")
+}
+
+func TestGuardPatterns(t *testing.T) {
+	testutil.SetFlag(t, cyclo.Analyzer, "guards", "commaok,nilcheck,logged,loop,panic")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, cyclo.Analyzer, "guards")
+}
+
+func TestNamedResults(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, cyclo.Analyzer, "namedresults")
+}
@@ -0,0 +1,55 @@
+package cyclo
+
+// Error guards are resolved with type information: the checked identifier
+// must be an error, and the guard may return a sentinel, a wrapped error or
+// an error composite literal instead of the identifier itself.
+
+var ErrBadInput error = &BadInputError{}
+
+// BadInputError is a concrete error type.
+type BadInputError struct{ field string }
+
+func (e *BadInputError) Error() string { return "bad input: " + e.field }
+
+func wrap(err error) error { return err }
+
+// SentinelGuard returns a sentinel. Complexity 1. Green zone.
+//
+//complexity:cyclo:warn=2,fail=3
+func SentinelGuard() (*int, error) {
+	if err := doSomething(); err != nil {
+		return nil, ErrBadInput
+	}
+	return nil, nil
+}
+
+// LiteralGuard returns an error composite literal. Complexity 1. Green zone.
+//
+//complexity:cyclo:warn=2,fail=3
+func LiteralGuard() error {
+	if err := doSomething(); err != nil {
+		return &BadInputError{field: "name"}
+	}
+	return nil
+}
+
+// WrappedGuard returns a wrapped error. Complexity 1. Green zone.
+//
+//complexity:cyclo:warn=2,fail=3
+func WrappedGuard() error {
+	if err := doSomething(); err != nil {
+		return wrap(err)
+	}
+	return nil
+}
+
+// PointerCheck is not an error guard: p is not an error. Complexity 2.
+// Yellow zone.
+//
+//complexity:cyclo:warn=2,fail=3
+func PointerCheck(p *int) (*int, any) { // want `function PointerCheck has cyclomatic complexity of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+	if p != nil {
+		return nil, p
+	}
+	return nil, nil
+}
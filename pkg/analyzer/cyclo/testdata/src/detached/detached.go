@@ -0,0 +1,67 @@
+package detached
+
+import (
+	"net/http"
+	"testing"
+)
+
+// handler is measured as handler.func1.
+var handler = func(w http.ResponseWriter, r *http.Request) { // want `function handler.func1 has cyclomatic complexity of 3 \(warn: >=3, fail: >=5\) \[warning\]`
+	if r.Method == http.MethodGet {
+		return
+	}
+	if r.URL == nil {
+		return
+	}
+}
+
+// Routes leaves the decisions of its handler to Routes.func1.
+func Routes(mux *http.ServeMux) {
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { // want `function Routes.func1 has cyclomatic complexity of 4 \(warn: >=3, fail: >=5\) \[warning\]`
+		for range 3 {
+		}
+		if r == nil {
+			return
+		}
+		switch {
+		case r.Method == "":
+		}
+	}))
+}
+
+// Table's subtest carries its own override and no longer fails Table.
+func Table(t *testing.T) {
+	//complexity:cyclo:warn=10,fail=20
+	t.Run("case", func(t *testing.T) {
+		for i := range 4 {
+			if i > 0 {
+				continue
+			}
+		}
+		if t == nil {
+			return
+		}
+		if t.Failed() {
+			return
+		}
+	})
+}
+
+// Outer and Outer.func1 are green once their literals are detached.
+func Outer() {
+	go func() {
+		defer func() { // want `function Outer.func1.1 has cyclomatic complexity of 3 \(warn: >=3, fail: >=5\) \[warning\]`
+			if recover() != nil {
+				return
+			}
+			if true {
+				return
+			}
+		}()
+	}()
+	_ = func() {
+		if false {
+			return
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package funclits
+
+import (
+	"net/http"
+	"testing"
+)
+
+// handler is measured as handler.func1.
+var handler = func(w http.ResponseWriter, r *http.Request) { // want `function handler.func1 has cyclomatic complexity of 3 \(warn: >=3, fail: >=5\) \[warning\]`
+	if r.Method == http.MethodGet {
+		return
+	}
+	if r.URL == nil {
+		return
+	}
+}
+
+// Routes is charged for the decisions of its handler as well.
+func Routes(mux *http.ServeMux) { // want `function Routes has cyclomatic complexity of 4 \(warn: >=3, fail: >=5\) \[warning\]`
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { // want `function Routes.func1 has cyclomatic complexity of 4 \(warn: >=3, fail: >=5\) \[warning\]`
+		for range 3 {
+		}
+		if r == nil {
+			return
+		}
+		switch {
+		case r.Method == "":
+		}
+	}))
+}
+
+// Table's subtest carries its own override.
+func Table(t *testing.T) { // want `function Table has cyclomatic complexity of 5 \(warn: >=3, fail: >=5\) \[error\]`
+	//complexity:cyclo:warn=10,fail=20
+	t.Run("case", func(t *testing.T) {
+		for i := range 4 {
+			if i > 0 {
+				continue
+			}
+		}
+		if t == nil {
+			return
+		}
+		if t.Failed() {
+			return
+		}
+	})
+}
+
+// Outer numbers nested literals after their parent.
+func Outer() { // want `function Outer has cyclomatic complexity of 4 \(warn: >=3, fail: >=5\) \[warning\]`
+	go func() { // want `function Outer.func1 has cyclomatic complexity of 3 \(warn: >=3, fail: >=5\) \[warning\]`
+		defer func() { // want `function Outer.func1.1 has cyclomatic complexity of 3 \(warn: >=3, fail: >=5\) \[warning\]`
+			if recover() != nil {
+				return
+			}
+			if true {
+				return
+			}
+		}()
+	}()
+	_ = func() {
+		if false {
+			return
+		}
+	}
+}
+
+func each(func(bool)) {}
+
+// OneLine's override is its own: its literal, on the same line, keeps the
+// defaults and is green at complexity 1.
+//
+//complexity:cyclo:warn=1,fail=2
+func OneLine() { each(func(bool) {}) } // want `function OneLine has cyclomatic complexity of 1 \(warn: >=1, fail: >=2\) \[warning\]`
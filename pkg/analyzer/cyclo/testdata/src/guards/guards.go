@@ -0,0 +1,89 @@
+package guards
+
+import "errors"
+
+var ErrMissing = errors.New("missing")
+
+type Config struct{ Name string }
+
+func check(string) error { return nil }
+
+func logError(error) {}
+
+// With every guard pattern enabled, each function below has complexity 1
+// (green) apart from the last.
+
+//complexity:cyclo:warn=2,fail=3
+func CommaOK(m map[string]int, k string) (int, error) {
+	v, ok := m[k]
+	if !ok {
+		return 0, ErrMissing
+	}
+	return v, nil
+}
+
+//complexity:cyclo:warn=2,fail=3
+func NilCheck(c *Config) (string, error) {
+	if c == nil {
+		return "", ErrMissing
+	}
+	return c.Name, nil
+}
+
+//complexity:cyclo:warn=2,fail=3
+func Logged(name string) error {
+	if err := check(name); err != nil {
+		logError(err)
+		return err
+	}
+	return nil
+}
+
+// Loop has complexity 2: the range counts, its continue guard does not.
+//
+//complexity:cyclo:warn=3,fail=4
+func Loop(names []string) {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		_ = name
+	}
+}
+
+//complexity:cyclo:warn=2,fail=3
+func Invariant(n int) int {
+	if n < 0 {
+		panic("negative")
+	}
+	return n
+}
+
+// NilReturn returns the nil pointer it checked; that is not a guard.
+// Complexity 2. Yellow zone.
+//
+//complexity:cyclo:warn=2,fail=3
+func NilReturn(c *Config) (*Config, any) { // want `function NilReturn has cyclomatic complexity of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+	if c == nil {
+		return nil, c
+	}
+	return c, nil
+}
+
+// SwitchBreak counts its break guard, which ends the switch case rather than
+// the loop. Complexity 4: range, case, if. Yellow zone.
+//
+//complexity:cyclo:warn=4,fail=5
+func SwitchBreak(names []string) int { // want `function SwitchBreak has cyclomatic complexity of 4 \(warn: >=4, fail: >=5\) \[warning\]`
+	n := 0
+	for _, name := range names {
+		switch {
+		case name != "":
+			if name == "stop" {
+				break
+			}
+			n++
+		}
+	}
+	return n
+}
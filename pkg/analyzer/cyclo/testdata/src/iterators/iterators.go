@@ -0,0 +1,46 @@
+package iterators
+
+import "iter"
+
+// Numbers has complexity 2 under -iterators: the range counts, the yield
+// exit does not. Green zone.
+//
+//complexity:cyclo:warn=3,fail=5
+func Numbers(xs []int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, x := range xs {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Pairs yields through a parameter with another name. Complexity 2. Green
+// zone.
+//
+//complexity:cyclo:warn=3,fail=5
+func Pairs(xs []int) iter.Seq2[int, int] {
+	return func(emit func(int, int) bool) {
+		for i, x := range xs {
+			if !emit(i, x) {
+				return
+			}
+		}
+	}
+}
+
+// Checked exits on a condition that is not a yield call: complexity 3.
+// Yellow zone.
+//
+//complexity:cyclo:warn=3,fail=5
+func Checked(xs []int, ok func(int) bool) iter.Seq[int] { // want `function Checked has cyclomatic complexity of 3 \(warn: >=3, fail: >=5\) \[warning\]`
+	return func(yield func(int) bool) {
+		for _, x := range xs {
+			if !ok(x) {
+				return
+			}
+			yield(x)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package namedresults
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrEmpty = errors.New("empty")
+
+func parse(string) (int, error) { return 0, nil }
+
+// Each guard below returns through the named results: complexity 1. Green
+// zone.
+
+//complexity:cyclo:warn=2,fail=3
+func Bare(s string) (n int, err error) {
+	n, err = parse(s)
+	if err != nil {
+		return
+	}
+	return n * 2, nil
+}
+
+//complexity:cyclo:warn=2,fail=3
+func Forwarded(s string) (n int, err error) {
+	n, err = parse(s)
+	if err != nil {
+		return n, err
+	}
+	return n * 2, nil
+}
+
+//complexity:cyclo:warn=2,fail=3
+func Wrapped(s string) (n int, err error) {
+	if n, err = parse(s); err != nil {
+		return n, fmt.Errorf("parse %q: %w", s, err)
+	}
+	return n * 2, nil
+}
+
+//complexity:cyclo:warn=2,fail=3
+func Sentinel(s string) (n int, err error) {
+	if _, e := parse(s); e != nil {
+		return 0, ErrEmpty
+	}
+	return len(s), nil
+}
+
+// Literal has no named results itself; the literal's guard is resolved
+// against the literal's signature.
+//
+//complexity:cyclo:warn=2,fail=3
+func Literal(s string) error {
+	step := func() (err error) {
+		if _, err = parse(s); err != nil {
+			return
+		}
+		return nil
+	}
+	return step()
+}
+
+// A bare return after checking another error returns the named err, not the
+// checked one: complexity 2. Yellow zone.
+//
+//complexity:cyclo:warn=2,fail=3
+func OtherError(s string) (n int, err error) { // want `function OtherError has cyclomatic complexity of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+	if _, e := parse(s); e != nil {
+		return
+	}
+	return len(s), nil
+}
+
+// Changed returns a value computed from n rather than n itself: complexity
+// 2. Yellow zone.
+//
+//complexity:cyclo:warn=2,fail=3
+func Changed(s string) (n int, err error) { // want `function Changed has cyclomatic complexity of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+	n, err = parse(s)
+	if err != nil {
+		return n + 1, err
+	}
+	return n, nil
+}
@@ -0,0 +1,249 @@
+package embeddepth
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "embeddepth",
+	Doc: "reports named types with deep struct or interface embedding\n\n" +
+		"Embedding depth is the longest chain of embedded struct fields or " +
+		"embedded interfaces reachable from a named type, resolved with type " +
+		"information across packages. Also reports promoted fields and methods " +
+		"that are ambiguous because the same name is reachable through more " +
+		"than one embedding path at the same depth.",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+var (
+	warnAt          int
+	failAt          int
+	ambiguityWarnAt int
+	ambiguityFailAt int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 3,
+		"embedding depth at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 5,
+		"embedding depth at or above this triggers a failure (red zone)")
+	Analyzer.Flags.IntVar(&ambiguityWarnAt, "ambiguity-warn", 2,
+		"embedding depth of an ambiguous promoted selector at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&ambiguityFailAt, "ambiguity-fail", 4,
+		"embedding depth of an ambiguous promoted selector at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := defaults.Validate("embeddepth"); err != nil {
+		return nil, err
+	}
+	ambiguityDefaults := common.Thresholds{WarnAt: ambiguityWarnAt, FailAt: ambiguityFailAt}
+	if err := ambiguityDefaults.Validate("embeddepth-ambiguity"); err != nil {
+		return nil, err
+	}
+
+	qualifier := func(p *types.Package) string {
+		if p == pass.Pkg {
+			return ""
+		}
+		return p.Name()
+	}
+	nodeFilter := []ast.Node{(*ast.GenDecl)(nil)}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		genDecl := n.(*ast.GenDecl)
+		if common.IsExcluded(pass.Fset.Position(genDecl.Pos()).Filename) {
+			return
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			tn, ok := pass.TypesInfo.Defs[typeSpec.Name].(*types.TypeName)
+			if !ok {
+				continue
+			}
+			named, ok := tn.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+
+			chain := deepestChain(named, make(map[*types.Named]bool))
+			thresholds := common.ParseDocOverrides("embeddepth", defaults, typeSpec.Doc, genDecl.Doc)
+			if zone := thresholds.Classify(len(chain)); zone != common.ZoneGreen {
+				pass.Report(analysis.Diagnostic{
+					Pos:      typeSpec.Name.Pos(),
+					Category: zone.Category(),
+					Message: fmt.Sprintf(
+						"type %s has an embedding depth of %d via %s (warn: >=%d, fail: >=%d) [%s] "+
+							"(reduce by replacing embedded types with named fields and explicit delegation)",
+						tn.Name(), len(chain), chainString(tn.Name(), chain, qualifier),
+						thresholds.WarnAt, thresholds.FailAt, zone.Category()),
+				})
+			}
+
+			ambiguityThresholds := common.ParseDocOverrides("embeddepth-ambiguity", ambiguityDefaults, typeSpec.Doc, genDecl.Doc)
+			for _, amb := range ambiguities(named) {
+				zone := ambiguityThresholds.Classify(amb.depth)
+				if zone == common.ZoneGreen {
+					continue
+				}
+				pass.Report(analysis.Diagnostic{
+					Pos:      typeSpec.Name.Pos(),
+					Category: zone.Category(),
+					Message: fmt.Sprintf(
+						"type %s has ambiguous promoted selector %s at embedding depth %d (warn: >=%d, fail: >=%d) [%s] "+
+							"(declare %s on %s to resolve it explicitly, or flatten the embedding)",
+						tn.Name(), amb.name, amb.depth,
+						ambiguityThresholds.WarnAt, ambiguityThresholds.FailAt, zone.Category(),
+						amb.name, tn.Name()),
+				})
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// embedded returns the named types directly embedded in named's struct or
+// interface. Pointer embeddings are dereferenced; other embedded elements
+// (unions, unnamed types) are not part of an embedding chain.
+func embedded(named *types.Named) []*types.Named {
+	var out []*types.Named
+	add := func(t types.Type) {
+		if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if n, ok := types.Unalias(t).(*types.Named); ok {
+			switch n.Underlying().(type) {
+			case *types.Struct, *types.Interface:
+				out = append(out, n)
+			}
+		}
+	}
+
+	switch u := named.Underlying().(type) {
+	case *types.Struct:
+		for i := range u.NumFields() {
+			if f := u.Field(i); f.Embedded() {
+				add(f.Type())
+			}
+		}
+	case *types.Interface:
+		for i := range u.NumEmbeddeds() {
+			add(u.EmbeddedType(i))
+		}
+	}
+	return out
+}
+
+// deepestChain returns the longest sequence of embedded named types reachable
+// from named. onPath guards against cycles through pointer embedding.
+func deepestChain(named *types.Named, onPath map[*types.Named]bool) []*types.Named {
+	origin := named.Origin()
+	if onPath[origin] {
+		return nil
+	}
+	onPath[origin] = true
+	defer delete(onPath, origin)
+
+	var deepest []*types.Named
+	for _, e := range embedded(named) {
+		if onPath[e.Origin()] {
+			continue
+		}
+		if chain := append([]*types.Named{e}, deepestChain(e, onPath)...); len(chain) > len(deepest) {
+			deepest = chain
+		}
+	}
+	return deepest
+}
+
+func chainString(name string, chain []*types.Named, qualifier types.Qualifier) string {
+	parts := []string{name}
+	for _, n := range chain {
+		parts = append(parts, types.TypeString(n, qualifier))
+	}
+	return strings.Join(parts, " -> ")
+}
+
+type ambiguity struct {
+	name  string
+	depth int
+}
+
+// ambiguities returns the promoted field and method names of named that
+// cannot be selected because they occur more than once at the shallowest
+// embedding depth where they appear.
+func ambiguities(named *types.Named) []ambiguity {
+	candidates := make(map[selector]bool)
+	collectPromoted(named, candidates, make(map[*types.Named]bool))
+
+	var out []ambiguity
+	for sel := range candidates {
+		obj, index, _ := types.LookupFieldOrMethod(named, true, sel.pkg, sel.name)
+		if obj == nil && index != nil {
+			// The last index selects the field or method itself; the rest
+			// are the embedding levels it is promoted through.
+			out = append(out, ambiguity{name: sel.name, depth: len(index) - 1})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out
+}
+
+// selector identifies a field or method name. Unexported names are scoped to
+// their package; pkg is nil for exported names.
+type selector struct {
+	pkg  *types.Package
+	name string
+}
+
+func selectorOf(obj types.Object) selector {
+	if obj.Exported() {
+		return selector{name: obj.Name()}
+	}
+	return selector{pkg: obj.Pkg(), name: obj.Name()}
+}
+
+// collectPromoted records the selector of every field and method declared on
+// a type embedded somewhere below named.
+func collectPromoted(named *types.Named, names map[selector]bool, seen map[*types.Named]bool) {
+	for _, e := range embedded(named) {
+		if seen[e.Origin()] {
+			continue
+		}
+		seen[e.Origin()] = true
+
+		for i := range e.NumMethods() {
+			names[selectorOf(e.Method(i))] = true
+		}
+		switch u := e.Underlying().(type) {
+		case *types.Struct:
+			for i := range u.NumFields() {
+				names[selectorOf(u.Field(i))] = true
+			}
+		case *types.Interface:
+			for i := range u.NumExplicitMethods() {
+				names[selectorOf(u.ExplicitMethod(i))] = true
+			}
+		}
+		collectPromoted(e, names, seen)
+	}
+}
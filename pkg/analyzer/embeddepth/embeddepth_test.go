@@ -0,0 +1,13 @@
+package embeddepth_test
+
+import (
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/embeddepth"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestEmbedDepth(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, embeddepth.Analyzer, "embeddepth")
+}
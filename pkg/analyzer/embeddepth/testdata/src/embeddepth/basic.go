@@ -0,0 +1,100 @@
+package embeddepth
+
+import (
+	"io"
+
+	"ext.pkg/base"
+)
+
+// Plain embeds nothing. Depth 0. Green zone.
+type Plain struct {
+	A int
+}
+
+// One embeds Plain. Depth 1. Green zone.
+type One struct {
+	Plain
+}
+
+// Two embeds One through a pointer. Depth 2. Green zone.
+type Two struct {
+	*One
+}
+
+// Three embeds Two. Depth 3. Yellow zone.
+type Three struct { // want `type Three has an embedding depth of 3 via Three -> Two -> One -> Plain \(warn: >=3, fail: >=5\) \[warning\] \(reduce by replacing embedded types with named fields and explicit delegation\)`
+	Two
+}
+
+// Four embeds Three. Depth 4. Yellow zone.
+type Four struct { // want `type Four has an embedding depth of 4`
+	Three
+}
+
+// Five embeds Four and base.Mid; the deepest chain wins. Depth 5. Red zone.
+type Five struct { // want `type Five has an embedding depth of 5 via Five -> Four -> Three -> Two -> One -> Plain \(warn: >=3, fail: >=5\) \[error\]`
+	Four
+	base.Mid
+}
+
+// CrossPackage follows embedding into ext.pkg/base. Depth 3. Yellow zone.
+type CrossPackage struct { // want `type CrossPackage has an embedding depth of 3 via CrossPackage -> Local -> base.Mid -> base.Root`
+	Local
+}
+
+type Local struct {
+	base.Mid
+}
+
+// ReadWriteCloser embeds interfaces that embed interfaces. Depth 2. Green zone.
+type ReadWriteCloser interface {
+	io.ReadWriteCloser
+}
+
+// DeepIface is an interface chain: DeepIface -> ReadWriteCloser -> io.ReadWriteCloser -> io.Reader. Depth 3.
+type DeepIface interface { // want `type DeepIface has an embedding depth of 3 via DeepIface -> ReadWriteCloser -> io.ReadWriteCloser -> io.Reader`
+	ReadWriteCloser
+}
+
+// Node embeds a pointer to itself; the cycle is not followed. Depth 0.
+type Node struct {
+	*Node
+	Val int
+}
+
+// Overridden is depth 3 but the override raises thresholds.
+//
+//complexity:embeddepth:warn=4,fail=6 Mirrors the protocol layering.
+type Overridden struct {
+	Two
+}
+
+// LeftA and RightA both embed Plain.
+type LeftA struct{ Plain }
+type RightA struct{ Plain }
+
+// Ambiguous reaches A through LeftA.Plain and RightA.Plain: promoted through
+// 2 embedding levels. Yellow zone. Plain itself is ambiguous at depth 1,
+// which is green by default.
+type Ambiguous struct { // want `type Ambiguous has ambiguous promoted selector A at embedding depth 2 \(warn: >=2, fail: >=4\) \[warning\] \(declare A on Ambiguous to resolve it explicitly, or flatten the embedding\)`
+	LeftA
+	RightA
+}
+
+// Shallow has two direct embeddings with the same promoted field ID at depth 1.
+// This is a common, deliberate pattern and is green by default.
+type Shallow struct {
+	base.Root
+	Plain2
+}
+
+type Plain2 struct{ ID int }
+
+// AmbiguousOverridden has the same depth-2 ambiguity as Ambiguous, but the
+// override raises the ambiguity thresholds.
+//
+//complexity:embeddepth-ambiguity:warn=3,fail=5
+type AmbiguousOverridden struct {
+	LeftA
+	RightA
+}
@@ -0,0 +1,16 @@
+package base
+
+// Root is the bottom of a cross-package embedding chain.
+type Root struct {
+	ID int
+}
+
+// Mid embeds Root.
+type Mid struct {
+	Root
+}
+
+// Logger is an interface from another package.
+type Logger interface {
+	Log(msg string)
+}
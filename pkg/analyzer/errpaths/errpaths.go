@@ -0,0 +1,162 @@
+package errpaths
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "errpaths",
+	Doc: "reports functions with many distinct error exits\n\n" +
+		"Counts the ways a function can fail: error guard clauses (the same " +
+		"pattern cyclo and nestdepth exempt), other return statements whose " +
+		"error result is not nil, and calls to panic. Returns and panics inside " +
+		"function literals belong to the literal and are not counted.",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+var (
+	warnAt int
+	failAt int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 8,
+		"error exit count at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 15,
+		"error exit count at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+	common.RegisterGuardsFlag(Analyzer)
+}
+
+// exits tallies a function's error exits by kind.
+type exits struct {
+	guards, returns, panics int
+}
+
+func (e exits) total() int {
+	return e.guards + e.returns + e.panics
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := defaults.Validate("errpaths"); err != nil {
+		return nil, err
+	}
+
+	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		funcDecl := n.(*ast.FuncDecl)
+		if funcDecl.Body == nil {
+			return
+		}
+		if common.IsExcluded(pass.Fset.Position(funcDecl.Pos()).Filename) {
+			return
+		}
+
+		funcName := common.FuncName(funcDecl)
+		thresholds := common.ParseOverrides(funcDecl, "errpaths", defaults)
+
+		e := countExits(pass, funcDecl)
+		zone := thresholds.Classify(e.total())
+
+		if zone == common.ZoneGreen {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      funcDecl.Pos(),
+			Category: zone.Category(),
+			Message: fmt.Sprintf(
+				"function %s has %d error exits (guards: %d, other returns: %d, panics: %d) (warn: >=%d, fail: >=%d) [%s] "+
+					"(reduce by extracting validation and setup steps into helpers that return one error, or by handling related failures at a single exit)",
+				funcName, e.total(), e.guards, e.returns, e.panics,
+				thresholds.WarnAt, thresholds.FailAt, zone.Category()),
+		})
+	})
+
+	return nil, nil
+}
+
+// countExits counts the error exits of funcDecl's body, skipping function
+// literals.
+func countExits(pass *analysis.Pass, funcDecl *ast.FuncDecl) exits {
+	returnsError := returnsError(pass, funcDecl)
+	resultGuards := common.ResultGuards(pass.TypesInfo, funcDecl)
+	guarded := make(map[*ast.ReturnStmt]bool)
+
+	var e exits
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.IfStmt:
+			if !returnsError || !(common.IsErrGuard(pass.TypesInfo, s) || resultGuards[s]) {
+				break
+			}
+			// Panic guards have no return; panics count below. A
+			// result guard's bare return returns the checked error.
+			ret, ok := s.Body.List[len(s.Body.List)-1].(*ast.ReturnStmt)
+			if ok && (resultGuards[s] || returnsNonNil(pass, ret)) {
+				guarded[ret] = true
+				e.guards++
+			}
+		case *ast.ReturnStmt:
+			if returnsError && !guarded[s] && returnsNonNil(pass, s) {
+				e.returns++
+			}
+		case *ast.CallExpr:
+			if isPanic(pass, s) {
+				e.panics++
+			}
+		}
+		return true
+	})
+	return e
+}
+
+// returnsError reports whether the last result of funcDecl is an error.
+func returnsError(pass *analysis.Pass, funcDecl *ast.FuncDecl) bool {
+	fn, ok := pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	results := fn.Type().(*types.Signature).Results()
+	if results.Len() == 0 {
+		return false
+	}
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	return types.Implements(results.At(results.Len()-1).Type(), errorType)
+}
+
+// returnsNonNil reports whether ret may return an error: its error result
+// is anything but nil, including a forwarded call such as return f(). Bare
+// returns of named results are not counted: their value is not visible at
+// the return statement. Bare returns in result guards are counted by
+// countExits.
+func returnsNonNil(pass *analysis.Pass, ret *ast.ReturnStmt) bool {
+	if len(ret.Results) == 0 {
+		return false
+	}
+	last := ret.Results[len(ret.Results)-1]
+	tv, ok := pass.TypesInfo.Types[last]
+	return !ok || !tv.IsNil()
+}
+
+func isPanic(pass *analysis.Pass, call *ast.CallExpr) bool {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	return pass.TypesInfo.ObjectOf(id) == types.Universe.Lookup("panic")
+}
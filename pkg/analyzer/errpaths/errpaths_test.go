@@ -0,0 +1,31 @@
+package errpaths_test
+
+import (
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/errpaths"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestErrPaths(t *testing.T) {
+	testutil.SetFlag(t, errpaths.Analyzer, "warn", "4")
+	testutil.SetFlag(t, errpaths.Analyzer, "fail", "6")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, errpaths.Analyzer, "errpaths")
+}
+
+func TestGuardPatterns(t *testing.T) {
+	testutil.SetFlag(t, errpaths.Analyzer, "warn", "4")
+	testutil.SetFlag(t, errpaths.Analyzer, "fail", "6")
+	testutil.SetFlag(t, errpaths.Analyzer, "guards", "commaok,nilcheck,logged,loop,panic")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, errpaths.Analyzer, "guards")
+}
+
+func TestNamedResults(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, errpaths.Analyzer, "namedresults")
+}
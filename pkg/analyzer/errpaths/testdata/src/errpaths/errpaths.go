@@ -0,0 +1,74 @@
+package errpaths
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var errEmpty = errors.New("empty")
+
+func parse(s string) (int, error) { // want `function parse has 5 error exits \(guards: 2, other returns: 2, panics: 1\) \(warn: >=4, fail: >=6\) \[warning\] \(reduce by extracting validation and setup steps into helpers that return one error, or by handling related failures at a single exit\)`
+	if s == "" {
+		return 0, errEmpty // other return: not a guard
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err // guard
+	}
+	m, err := strconv.Atoi(s + "0")
+	if err != nil {
+		return 0, fmt.Errorf("parse %q: %w", s, err) // guard
+	}
+	if n < 0 {
+		panic("negative")
+	}
+	check := func() error {
+		return errEmpty // belongs to the literal
+	}
+	_ = check
+	if m > n {
+		return m, nil // success
+	}
+	return n, validate(n) // forwards validate's error
+}
+
+func validate(n int) error {
+	if n > 100 {
+		return errEmpty
+	}
+	return nil
+}
+
+// noError returns no error; only its panics count.
+func noError(xs []int) int { // want `function noError has 4 error exits \(guards: 0, other returns: 0, panics: 4\)`
+	switch len(xs) {
+	case 0:
+		panic("empty")
+	case 1:
+		panic("short")
+	case 2:
+		panic("pair")
+	}
+	if xs[0] < 0 {
+		panic(errEmpty)
+	}
+	return xs[0]
+}
+
+//complexity:errpaths:warn=10,fail=20 Decodes a wire format field by field.
+func overridden(a, b, c, d string) error {
+	if _, err := strconv.Atoi(a); err != nil {
+		return err
+	}
+	if _, err := strconv.Atoi(b); err != nil {
+		return err
+	}
+	if _, err := strconv.Atoi(c); err != nil {
+		return err
+	}
+	if _, err := strconv.Atoi(d); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package guards
+
+import "errors"
+
+var (
+	ErrMissing = errors.New("missing")
+	ErrTooBig  = errors.New("too big")
+)
+
+func check(int) error { return nil }
+
+func logError(error) {}
+
+// Load has 4 error exits with every guard pattern enabled: the comma-ok and
+// logged guards return errors, the panic guard panics and the last check is
+// an ordinary return. The loop guard is not an exit.
+func Load(m map[string]int, keys []string) (int, error) { // want `function Load has 4 error exits \(guards: 2, other returns: 1, panics: 1\) \(warn: >=4, fail: >=6\) \[warning\]`
+	for _, k := range keys {
+		if k == "" {
+			continue
+		}
+		v, ok := m[k]
+		if !ok {
+			return 0, ErrMissing
+		}
+		if v < 0 {
+			panic("negative value")
+		}
+		if err := check(v); err != nil {
+			logError(err)
+			return 0, err
+		}
+		if v > 10 {
+			return 0, ErrTooBig
+		}
+	}
+	return 0, nil
+}
+
+// Found returns no error from its comma-ok guard: 3 exits. Green zone.
+func Found(m map[string]int, k string) (bool, error) {
+	if _, ok := m[k]; !ok {
+		return false, nil
+	}
+	if err := check(m[k]); err != nil {
+		return false, err
+	}
+	if m[k] > 10 {
+		return false, ErrTooBig
+	}
+	return true, check(0)
+}
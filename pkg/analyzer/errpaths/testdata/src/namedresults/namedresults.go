@@ -0,0 +1,27 @@
+package namedresults
+
+import "errors"
+
+var ErrEmpty = errors.New("empty")
+
+func parse(string) (int, error) { return 0, nil }
+
+// Load has 3 error exits: the bare and forwarding guards return through the
+// named results, and the empty check is an ordinary return. The final bare
+// return's value is not visible and is not counted.
+//
+//complexity:errpaths:warn=3,fail=5
+func Load(a, b string) (n int, err error) { // want `function Load has 3 error exits \(guards: 2, other returns: 1, panics: 0\) \(warn: >=3, fail: >=5\) \[warning\]`
+	if a == "" {
+		return 0, ErrEmpty
+	}
+	if n, err = parse(a); err != nil {
+		return
+	}
+	var m int
+	if m, err = parse(b); err != nil {
+		return n, err
+	}
+	n += m
+	return
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/types"
+	"reflect"
 
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
 	"golang.org/x/tools/go/analysis"
@@ -15,10 +16,12 @@ var Analyzer = &analysis.Analyzer{
 	Name: "fanout",
 	Doc: "reports functions with too many distinct function calls (fan out)\n\n" +
 		"Counts unique non-builtin, non-stdlib function/method calls in a function. " +
-		"The same function called multiple times counts as 1. " +
+		"The same function called multiple times counts as 1, and so do all " +
+		"instantiations of a generic function or method. " +
 		"Calls in idiomatic error guard return expressions are omitted.",
-	Run:      run,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:        run,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	ResultType: reflect.TypeOf(Result(nil)),
 }
 
 var (
@@ -33,8 +36,15 @@ func init() {
 		"fan out count at or above this triggers a failure (red zone)")
 	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
 		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+	common.RegisterFuncLitFlags(Analyzer)
+	common.RegisterGuardsFlag(Analyzer)
 }
 
+// Result maps each analyzed function to its measure. Functions without a
+// body and functions in excluded files are absent, and so are function
+// literals measured as units of their own.
+type Result map[*ast.FuncDecl]common.Measure
+
 func run(pass *analysis.Pass) (any, error) {
 	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
@@ -42,6 +52,7 @@ func run(pass *analysis.Pass) (any, error) {
 		return nil, err
 	}
 
+	result := make(Result)
 	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
 
 	insp.Preorder(nodeFilter, func(n ast.Node) {
@@ -53,18 +64,26 @@ func run(pass *analysis.Pass) (any, error) {
 			return
 		}
 
-		funcName := common.FuncName(funcDecl)
 		thresholds := common.ParseOverrides(funcDecl, "fanout", defaults)
+		distinctCalls := countDistinctCalls(pass, funcDecl, funcDecl.Body)
+		result[funcDecl] = check(pass, funcDecl, common.FuncName(funcDecl), distinctCalls, thresholds)
+	})
 
-		distinctCalls := countDistinctCalls(pass, funcDecl.Body)
-		zone := thresholds.Classify(distinctCalls)
+	for _, unit := range common.PassFuncLitUnits(pass) {
+		thresholds := common.ParseDocOverrides("fanout", defaults, unit.Docs...)
+		check(pass, unit.Lit, unit.Name, countDistinctCalls(pass, unit.Lit, unit.Lit.Body), thresholds)
+	}
 
-		if zone == common.ZoneGreen {
-			return
-		}
+	return result, nil
+}
 
+// check classifies a function's fan out and reports it outside the
+// green zone.
+func check(pass *analysis.Pass, node ast.Node, funcName string, distinctCalls int, thresholds common.Thresholds) common.Measure {
+	zone := thresholds.Classify(distinctCalls)
+	if zone != common.ZoneGreen {
 		pass.Report(analysis.Diagnostic{
-			Pos:      funcDecl.Pos(),
+			Pos:      node.Pos(),
 			Category: zone.Category(),
 			Message: fmt.Sprintf(
 				"function %s has fan out of %d (warn: >=%d, fail: >=%d) [%s] "+
@@ -72,18 +91,23 @@ func run(pass *analysis.Pass) (any, error) {
 				funcName, distinctCalls, thresholds.WarnAt, thresholds.FailAt,
 				zone.Category()),
 		})
-	})
-
-	return nil, nil
+	}
+	return common.Measure{Value: distinctCalls, Thresholds: thresholds, Zone: zone}
 }
 
 // countDistinctCalls counts the number of distinct non-builtin, non-stdlib
-// function/method calls in a function body.
-func countDistinctCalls(pass *analysis.Pass, body *ast.BlockStmt) int {
+// function/method calls in a function body. fn is the declaration or literal
+// owning body.
+func countDistinctCalls(pass *analysis.Pass, fn ast.Node, body *ast.BlockStmt) int {
 	seen := make(map[types.Object]bool)
-	errGuardCalls := common.ErrGuardCallExprs(body)
+	errGuardCalls := common.ErrGuardCallExprs(pass.TypesInfo, fn)
+	yields := yieldParams(pass, fn)
 
 	ast.Inspect(body, func(n ast.Node) bool {
+		// Detached literals are measured as units of their own.
+		if _, ok := n.(*ast.FuncLit); ok && common.FuncLitsDetached() {
+			return false
+		}
 		call, ok := n.(*ast.CallExpr)
 		if !ok {
 			return true
@@ -92,18 +116,8 @@ func countDistinctCalls(pass *analysis.Pass, body *ast.BlockStmt) int {
 			return true
 		}
 
-		var obj types.Object
-
-		switch fn := call.Fun.(type) {
-		case *ast.Ident:
-			obj = pass.TypesInfo.ObjectOf(fn)
-		case *ast.SelectorExpr:
-			obj = pass.TypesInfo.ObjectOf(fn.Sel)
-		default:
-			return true
-		}
-
-		if obj == nil {
+		obj := callee(pass.TypesInfo, call.Fun)
+		if obj == nil || yields[obj] {
 			return true
 		}
 
@@ -133,3 +147,58 @@ func countDistinctCalls(pass *analysis.Pass, body *ast.BlockStmt) int {
 
 	return len(seen)
 }
+
+// callee returns the object a call expression's function resolves to: a
+// function, method, or a variable or struct field of func type. Parentheses
+// and explicit instantiations (Map[int, string]) are looked through, and
+// instantiated generic functions, methods and fields resolve to their
+// generic origin so every instantiation counts once. It returns nil for
+// other callees, such as indexed func slices or called function results.
+func callee(info *types.Info, fun ast.Expr) types.Object {
+	var obj types.Object
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		obj = info.ObjectOf(f)
+	case *ast.SelectorExpr:
+		obj = info.ObjectOf(f.Sel)
+	case *ast.IndexExpr:
+		return instantiated(info, f.X)
+	case *ast.IndexListExpr:
+		return instantiated(info, f.X)
+	}
+	switch o := obj.(type) {
+	case *types.Func:
+		return o.Origin()
+	case *types.Var:
+		return o.Origin()
+	}
+	return obj
+}
+
+// instantiated returns the callee of an explicit instantiation x[...] when
+// x is a generic function, or nil when x is indexed otherwise.
+func instantiated(info *types.Info, x ast.Expr) types.Object {
+	fn, ok := callee(info, x).(*types.Func)
+	if !ok || fn.Type().(*types.Signature).TypeParams().Len() == 0 {
+		return nil
+	}
+	return fn
+}
+
+// yieldParams returns the yield parameters of the iterator literals in fn.
+// Calling yield hands a value to the loop consuming the iterator, so it is
+// not fan out.
+func yieldParams(pass *analysis.Pass, fn ast.Node) map[types.Object]bool {
+	yields := make(map[types.Object]bool)
+	ast.Inspect(fn, func(n ast.Node) bool {
+		lit, ok := n.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		if yield, ok := common.IteratorYield(lit); ok && yield != nil {
+			yields[pass.TypesInfo.Defs[yield]] = true
+		}
+		return true
+	})
+	return yields
+}
@@ -3,6 +3,7 @@ package fanout_test
 import (
 	"testing"
 
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/fanout"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
@@ -11,3 +12,39 @@ func TestFanout(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, fanout.Analyzer, "fanout")
 }
+
+func TestFuncLits(t *testing.T) {
+	testutil.SetFlag(t, fanout.Analyzer, "warn", "2")
+	testutil.SetFlag(t, fanout.Analyzer, "fail", "3")
+	testutil.SetFlag(t, fanout.Analyzer, "funclits", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, fanout.Analyzer, "funclits")
+}
+
+func TestFuncLitsDetached(t *testing.T) {
+	testutil.SetFlag(t, fanout.Analyzer, "warn", "2")
+	testutil.SetFlag(t, fanout.Analyzer, "fail", "3")
+	testutil.SetFlag(t, fanout.Analyzer, "funclits", "true")
+	testutil.SetFlag(t, fanout.Analyzer, "funclits-detach", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, fanout.Analyzer, "detached")
+}
+
+func TestGuardPatterns(t *testing.T) {
+	testutil.SetFlag(t, fanout.Analyzer, "guards", "commaok,nilcheck,logged,loop,panic")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, fanout.Analyzer, "guards")
+}
+
+func TestNamedResults(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, fanout.Analyzer, "namedresults")
+}
+
+func TestGenerics(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, fanout.Analyzer, "generics")
+}
@@ -0,0 +1,35 @@
+package detached
+
+import (
+	"net/http"
+	"testing"
+)
+
+func load()  {}
+func save()  {}
+func check() {}
+
+// handler is measured as handler.func1.
+var handler = func(w http.ResponseWriter, r *http.Request) { // want `function handler.func1 has fan out of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+	load()
+	save()
+}
+
+// Routes leaves the calls of its handler to Routes.func1.
+func Routes(mux *http.ServeMux) {
+	check()
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { // want `function Routes.func1 has fan out of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+		load()
+		save()
+	}))
+}
+
+// Table's subtest carries its own override and no longer fails Table.
+func Table(t *testing.T) {
+	//complexity:fanout:warn=5,fail=8
+	t.Run("case", func(t *testing.T) {
+		load()
+		save()
+		check()
+	})
+}
@@ -0,0 +1,36 @@
+package fanout
+
+import "iter"
+
+// Numbers calls helper and yield. Calling yield is not fan out, so the fan
+// out is 1. Green zone.
+//
+//complexity:fanout:warn=2,fail=3
+func Numbers() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		if !yield(helper()) {
+			return
+		}
+	}
+}
+
+// Pairs is an iter.Seq2 producer: the fan out is 1. Green zone.
+//
+//complexity:fanout:warn=2,fail=3
+func Pairs() iter.Seq2[int, int] {
+	return func(emit func(int, int) bool) {
+		for i := range 3 {
+			if !emit(i, helper()) {
+				return
+			}
+		}
+	}
+}
+
+// Visit takes a callback, not a yield parameter: calling it is fan out 2.
+// Yellow zone.
+//
+//complexity:fanout:warn=2,fail=3
+func Visit(visit func(int) bool) { // want `function Visit has fan out of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+	visit(helper())
+}
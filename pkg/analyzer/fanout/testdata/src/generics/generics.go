@@ -0,0 +1,70 @@
+package generics
+
+func Map[T, U any](xs []T, f func(T) U) []U { return nil }
+
+func Filter[T any](xs []T, keep func(T) bool) []T { return nil }
+
+func Pair[K comparable, V any](k K, v V) {}
+
+func format(int) string { return "" }
+
+type List[T any] struct{ items []T }
+
+func (l *List[T]) Push(v T) {}
+
+type Box[T any] struct{ Fn func(T) }
+
+type Hooks struct {
+	OnStart func()
+	OnStop  func()
+}
+
+// Instantiated calls Map twice with different type arguments, Filter and
+// Pair: fan out 3. Passing format is not a call. Yellow zone.
+//
+//complexity:fanout:warn=3,fail=5
+func Instantiated(xs []int) { // want `function Instantiated has fan out of 3 \(warn: >=3, fail: >=5\) \[warning\]`
+	_ = Map[int, string](xs, format)
+	_ = Map[int, int](xs, nil)
+	_ = Filter[int](xs, nil)
+	Pair[string, int]("a", 1)
+}
+
+// Inferred instantiations of the same function count once with explicit
+// ones: fan out 1. Green zone.
+//
+//complexity:fanout:warn=2,fail=3
+func Inferred(xs []int, names []string) {
+	_ = Filter(xs, nil)
+	_ = Filter(names, nil)
+	_ = (Filter[int])(xs, nil)
+}
+
+// Push on two instantiations of List is one method: fan out 1. Green zone.
+//
+//complexity:fanout:warn=2,fail=3
+func Methods(a *List[int], b *List[string]) {
+	a.Push(1)
+	b.Push("x")
+	(a.Push)(2)
+}
+
+// Calls through func-typed struct fields count per field, across
+// instantiations of a generic struct: OnStart, OnStop and Fn. Fan out 3.
+// Yellow zone.
+//
+//complexity:fanout:warn=3,fail=5
+func Fields(h Hooks, a Box[int], b Box[string]) { // want `function Fields has fan out of 3 \(warn: >=3, fail: >=5\) \[warning\]`
+	h.OnStart()
+	(h.OnStop)()
+	a.Fn(1)
+	b.Fn("x")
+}
+
+// Indexing a slice of funcs is not an instantiation and not counted: fan
+// out 0.
+//
+//complexity:fanout:warn=1,fail=2
+func Indexed(handlers []func()) {
+	handlers[0]()
+}
@@ -0,0 +1,29 @@
+package guards
+
+func check(int) error { return nil }
+
+func logError(error) {}
+
+func describe(int) string { return "" }
+
+// Guarded calls check; the logging call of its logged guard and the
+// describe call in its panic guard are boilerplate. Fan out 1. Green zone.
+//
+//complexity:fanout:warn=2,fail=3
+func Guarded(v int) error {
+	if v < 0 {
+		panic(describe(v))
+	}
+	if err := check(v); err != nil {
+		logError(err)
+		return err
+	}
+	return nil
+}
+
+// Unguarded logs outside a guard: fan out 2. Yellow zone.
+//
+//complexity:fanout:warn=2,fail=3
+func Unguarded(v int) { // want `function Unguarded has fan out of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+	logError(check(v))
+}
@@ -0,0 +1,16 @@
+package namedresults
+
+func parse(string) (int, error) { return 0, nil }
+
+func wrap(err error) error { return err }
+
+// Load calls parse; the wrap call in its named-result guard is boilerplate.
+// Fan out 1. Green zone.
+//
+//complexity:fanout:warn=2,fail=3
+func Load(s string) (n int, err error) {
+	if n, err = parse(s); err != nil {
+		return n, wrap(err)
+	}
+	return n, nil
+}
@@ -0,0 +1,120 @@
+package filesize
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"golang.org/x/tools/go/analysis"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "filesize",
+	Doc: "reports Go files with too many lines, declarations or functions\n\n" +
+		"Lines are the physical lines of the file, comments and blank lines " +
+		"included. Declarations are the top-level functions, methods, types, " +
+		"constants and variables (each spec of a grouped declaration counts); " +
+		"imports are not counted. Functions are the top-level functions and " +
+		"methods.",
+	Run: run,
+}
+
+var (
+	warnAt      int
+	failAt      int
+	declsWarnAt int
+	declsFailAt int
+	funcsWarnAt int
+	funcsFailAt int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 750,
+		"file line count at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 1500,
+		"file line count at or above this triggers a failure (red zone)")
+	Analyzer.Flags.IntVar(&declsWarnAt, "decls-warn", 40,
+		"top-level declarations in a file at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&declsFailAt, "decls-fail", 80,
+		"top-level declarations in a file at or above this triggers a failure (red zone)")
+	Analyzer.Flags.IntVar(&funcsWarnAt, "funcs-warn", 25,
+		"functions and methods in a file at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&funcsFailAt, "funcs-fail", 50,
+		"functions and methods in a file at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+// metric is one of the file measurements with its thresholds.
+type metric struct {
+	name     string // override metric name
+	what     string // rendered after the count, e.g. "lines"
+	value    int
+	defaults common.Thresholds
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	metrics := []metric{
+		{name: "filesize", what: "lines", defaults: common.Thresholds{WarnAt: warnAt, FailAt: failAt}},
+		{name: "filesize-decls", what: "top-level declarations", defaults: common.Thresholds{WarnAt: declsWarnAt, FailAt: declsFailAt}},
+		{name: "filesize-funcs", what: "functions", defaults: common.Thresholds{WarnAt: funcsWarnAt, FailAt: funcsFailAt}},
+	}
+	for _, m := range metrics {
+		if err := m.defaults.Validate(m.name); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range pass.Files {
+		tf := pass.Fset.File(file.Pos())
+		if tf == nil || common.IsExcluded(tf.Name()) {
+			continue
+		}
+		decls, funcs := countDecls(file)
+		metrics[0].value = tf.LineCount()
+		metrics[1].value = decls
+		metrics[2].value = funcs
+		for _, m := range metrics {
+			report(pass, file, m)
+		}
+	}
+
+	return nil, nil
+}
+
+func report(pass *analysis.Pass, file *ast.File, m metric) {
+	thresholds := common.ParseDocOverrides(m.name, m.defaults, file.Doc)
+	zone := thresholds.Classify(m.value)
+	if zone == common.ZoneGreen {
+		return
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:      file.Package,
+		Category: zone.Category(),
+		Message: fmt.Sprintf(
+			"file %s has %d %s (warn: >=%d, fail: >=%d) [%s] "+
+				"(reduce by splitting the file along its groups of related types and functions)",
+			filepath.Base(pass.Fset.Position(file.Package).Filename), m.value, m.what,
+			thresholds.WarnAt, thresholds.FailAt, zone.Category()),
+	})
+}
+
+// countDecls returns the number of top-level declarations in file, counting
+// each spec of a grouped declaration, and how many of them are functions or
+// methods. Imports are not counted.
+func countDecls(file *ast.File) (decls, funcs int) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			decls++
+			funcs++
+		case *ast.GenDecl:
+			if d.Tok != token.IMPORT {
+				decls += len(d.Specs)
+			}
+		}
+	}
+	return decls, funcs
+}
@@ -0,0 +1,22 @@
+package filesize_test
+
+import (
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/filesize"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestFileSize(t *testing.T) {
+	testutil.SetFlag(t, filesize.Analyzer, "warn", "15")
+	testutil.SetFlag(t, filesize.Analyzer, "fail", "30")
+	testutil.SetFlag(t, filesize.Analyzer, "decls-warn", "5")
+	testutil.SetFlag(t, filesize.Analyzer, "decls-fail", "10")
+	testutil.SetFlag(t, filesize.Analyzer, "funcs-warn", "3")
+	testutil.SetFlag(t, filesize.Analyzer, "funcs-fail", "4")
+	testutil.SetFlag(t, filesize.Analyzer, "exclude", "gen_*.go")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, filesize.Analyzer, "filesize", "overridden")
+}
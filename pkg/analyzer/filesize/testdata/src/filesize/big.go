@@ -0,0 +1,19 @@
+package filesize // want `file big.go has 19 lines \(warn: >=15, fail: >=30\) \[warning\] \(reduce by splitting the file along its groups of related types and functions\)` `file big.go has 6 top-level declarations \(warn: >=5, fail: >=10\) \[warning\]` `file big.go has 3 functions \(warn: >=3, fail: >=4\) \[warning\]`
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	a = 1
+	b = 2
+)
+
+type T struct{}
+
+func (T) String() string { return fmt.Sprint(a, b) }
+
+func upper(s string) string { return strings.ToUpper(s) }
+
+func lower(s string) string { return strings.ToLower(s) }
@@ -0,0 +1,4 @@
+package filesize
+
+// small stays green on every metric.
+func small() {}
@@ -0,0 +1,6 @@
+package overridden
+
+func p() {}
+func q() {}
+func r() {}
+func s() {}
@@ -0,0 +1,21 @@
+// Generated-style routing table kept in one file on purpose.
+//
+//complexity:filesize-funcs:warn=20,fail=30 One handler per route.
+//complexity:filesize-decls:warn=20,fail=30
+package overridden // want `file handlers.go has 21 lines`
+
+func a() {}
+func b() {}
+func c() {}
+func d() {}
+func e() {}
+func f() {}
+func g() {}
+func h() {}
+func i() {}
+func j() {}
+func k() {}
+func l() {}
+func m() {}
+func n() {}
+func o() {}
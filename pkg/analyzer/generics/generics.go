@@ -0,0 +1,221 @@
+package generics
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "generics",
+	Doc: "reports generic functions and types with too many type parameters or complex constraints\n\n" +
+		"Counts the type parameters of each generic function and type declaration, " +
+		"and scores their constraints: each union term, embedded constraint, " +
+		"constraint method and generic instantiation adds 1. Named constraints " +
+		"such as any, comparable or cmp.Ordered are free.",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+var (
+	warnAt           int
+	failAt           int
+	constraintWarnAt int
+	constraintFailAt int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 3,
+		"type parameter count at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 5,
+		"type parameter count at or above this triggers a failure (red zone)")
+	Analyzer.Flags.IntVar(&constraintWarnAt, "constraint-warn", 5,
+		"constraint complexity at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&constraintFailAt, "constraint-fail", 8,
+		"constraint complexity at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+// decl is a generic function or type declaration.
+type decl struct {
+	kind       string // "function" or "type"
+	name       string
+	pos        token.Pos
+	typeParams *ast.FieldList
+	docs       []*ast.CommentGroup
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	countDefaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := countDefaults.Validate("generics"); err != nil {
+		return nil, err
+	}
+	constraintDefaults := common.Thresholds{WarnAt: constraintWarnAt, FailAt: constraintFailAt}
+	if err := constraintDefaults.Validate("generics-constraint"); err != nil {
+		return nil, err
+	}
+
+	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil), (*ast.GenDecl)(nil)}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		if common.IsExcluded(pass.Fset.Position(n.Pos()).Filename) {
+			return
+		}
+
+		for _, d := range genericDecls(n) {
+			count := countTypeParams(d.typeParams)
+			countThresholds := common.ParseDocOverrides("generics", countDefaults, d.docs...)
+			report(pass, d, count, fmt.Sprintf("%d type parameters", count), countThresholds,
+				"reduce by fixing type parameters that only ever take one type argument or by grouping related type parameters behind a single constraint")
+
+			complexity := constraintComplexity(d.typeParams)
+			constraintThresholds := common.ParseDocOverrides("generics-constraint", constraintDefaults, d.docs...)
+			report(pass, d, complexity, fmt.Sprintf("constraint complexity of %d", complexity), constraintThresholds,
+				"reduce by declaring named constraint interfaces for unions and embedded constraints")
+		}
+	})
+
+	return nil, nil
+}
+
+// genericDecls returns the generic declarations introduced by n: a generic
+// function, or the generic type specs of a type declaration.
+func genericDecls(n ast.Node) []decl {
+	switch d := n.(type) {
+	case *ast.FuncDecl:
+		if d.Type.TypeParams == nil {
+			return nil
+		}
+		return []decl{{
+			kind:       "function",
+			name:       common.FuncName(d),
+			pos:        d.Pos(),
+			typeParams: d.Type.TypeParams,
+			docs:       []*ast.CommentGroup{d.Doc},
+		}}
+	case *ast.GenDecl:
+		var decls []decl
+		for _, spec := range d.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok || ts.TypeParams == nil {
+				continue
+			}
+			decls = append(decls, decl{
+				kind:       "type",
+				name:       ts.Name.Name,
+				pos:        ts.Pos(),
+				typeParams: ts.TypeParams,
+				docs:       []*ast.CommentGroup{ts.Doc, d.Doc},
+			})
+		}
+		return decls
+	}
+	return nil
+}
+
+// report emits a diagnostic for value when it falls outside the green zone.
+// measure describes the value for the message (e.g. "4 type parameters").
+func report(pass *analysis.Pass, d decl, value int, measure string, thresholds common.Thresholds, advice string) {
+	zone := thresholds.Classify(value)
+	if zone == common.ZoneGreen {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      d.pos,
+		Category: zone.Category(),
+		Message: fmt.Sprintf(
+			"%s %s has %s (warn: >=%d, fail: >=%d) [%s] (%s)",
+			d.kind, d.name, measure, thresholds.WarnAt, thresholds.FailAt,
+			zone.Category(), advice),
+	})
+}
+
+// countTypeParams counts type parameters, handling grouped names:
+// [K, V comparable, E any] has 3 type parameters.
+func countTypeParams(fl *ast.FieldList) int {
+	count := 0
+	for _, field := range fl.List {
+		count += len(field.Names)
+	}
+	return count
+}
+
+// constraintComplexity sums the complexity of each type parameter's
+// constraint. Grouped names share one constraint, which is scored once.
+func constraintComplexity(fl *ast.FieldList) int {
+	total := 0
+	for _, field := range fl.List {
+		total += constraintScore(field.Type)
+	}
+	return total
+}
+
+// constraintScore scores a constraint expression. Named constraints are free;
+// every union term, tilde term, instantiation, embedded constraint and
+// constraint method adds 1.
+func constraintScore(expr ast.Expr) int {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return constraintScore(e.X)
+	case *ast.BinaryExpr:
+		if e.Op != token.OR {
+			return 0
+		}
+		return unionTermScore(e.X) + unionTermScore(e.Y)
+	case *ast.UnaryExpr:
+		// ~T
+		return 1 + constraintScore(e.X)
+	case *ast.IndexExpr:
+		return 1 + constraintScore(e.X) + constraintScore(e.Index)
+	case *ast.IndexListExpr:
+		score := 1 + constraintScore(e.X)
+		for _, index := range e.Indices {
+			score += constraintScore(index)
+		}
+		return score
+	case *ast.InterfaceType:
+		return interfaceScore(e)
+	case *ast.Ident, *ast.SelectorExpr:
+		return 0
+	default:
+		// Type literals used as terms (e.g. []E).
+		return 1
+	}
+}
+
+// unionTermScore scores one side of a union: every term costs at least 1,
+// including plain named types.
+func unionTermScore(expr ast.Expr) int {
+	if bin, ok := expr.(*ast.BinaryExpr); ok && bin.Op == token.OR {
+		return constraintScore(bin)
+	}
+	return max(1, constraintScore(expr))
+}
+
+// interfaceScore scores an interface literal used as a constraint. Methods
+// and embedded named constraints cost 1 each; union and tilde elements cost
+// their terms.
+func interfaceScore(it *ast.InterfaceType) int {
+	score := 0
+	for _, field := range it.Methods.List {
+		if len(field.Names) > 0 {
+			score++
+			continue
+		}
+		switch field.Type.(type) {
+		case *ast.BinaryExpr, *ast.UnaryExpr:
+			score += constraintScore(field.Type)
+		default:
+			score += 1 + constraintScore(field.Type)
+		}
+	}
+	return score
+}
@@ -0,0 +1,13 @@
+package generics_test
+
+import (
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/generics"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestGenerics(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, generics.Analyzer, "generics")
+}
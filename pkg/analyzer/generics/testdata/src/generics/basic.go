@@ -0,0 +1,67 @@
+package generics
+
+import "fmt"
+
+// Identity has 1 type parameter with a free constraint. Green zone.
+func Identity[T any](v T) T { return v }
+
+// Pair has 2 type parameters. Green zone.
+type Pair[K comparable, V any] struct {
+	Key K
+	Val V
+}
+
+// Method on a generic type does not declare new type parameters. Green zone.
+func (p Pair[K, V]) Swap() Pair[K, V] { return p }
+
+// Three has 3 type parameters, grouped names included. Yellow zone.
+func Three[A, B any, C comparable](a A, b B, c C) {} // want `function Three has 3 type parameters \(warn: >=3, fail: >=5\) \[warning\] \(reduce by fixing type parameters that only ever take one type argument or by grouping related type parameters behind a single constraint\)`
+
+// Five has 5 type parameters. Red zone.
+func Five[A, B, C, D, E any]() {} // want `function Five has 5 type parameters \(warn: >=3, fail: >=5\) \[error\]`
+
+// Table is a generic type with 4 type parameters. Yellow zone.
+type Table[R, C comparable, V any, M ~map[R]V] struct{} // want `type Table has 4 type parameters \(warn: >=3, fail: >=5\) \[warning\]`
+
+// Number is a named constraint; naming it keeps users simple.
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Sum uses the named constraint. Constraint complexity 0. Green zone.
+func Sum[T Number](xs []T) T {
+	var s T
+	for _, x := range xs {
+		s += x
+	}
+	return s
+}
+
+// InlineUnion has an inline union of 5 terms. Constraint complexity 5. Yellow zone.
+func InlineUnion[T ~int | ~int8 | ~int16 | ~int32 | ~int64](v T) T { return v } // want `function InlineUnion has constraint complexity of 5 \(warn: >=5, fail: >=8\) \[warning\] \(reduce by declaring named constraint interfaces for unions and embedded constraints\)`
+
+// Convoluted scores 7 for T: an embedded constraint (1), a method (1), a union
+// of 3 terms (3) and an embedded instantiation (1 + 1). S instantiates with a
+// nested instantiation (2). Total 9. Red zone.
+func Convoluted[T interface { // want `function Convoluted has constraint complexity of 9 \(warn: >=5, fail: >=8\) \[error\]`
+	fmt.Stringer
+	Key() string
+	~int | ~string | float64
+	Container[int]
+}, S Container[Container[T]]]() {
+}
+
+// Container is a generic constraint.
+type Container[E any] interface {
+	Items() []E
+}
+
+// Overridden has 4 type parameters but the override raises thresholds.
+//
+//complexity:generics:warn=5,fail=6 Mirrors the upstream API.
+func Overridden[A, B, C, D any]() {}
+
+// OverriddenConstraint has an override for its constraint complexity.
+//
+//complexity:generics-constraint:warn=10,fail=12
+func OverriddenConstraint[T ~int | ~int8 | ~int16 | ~int32 | ~int64]() {}
@@ -0,0 +1,170 @@
+package importdepth
+
+import (
+	"fmt"
+	"go/ast"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"golang.org/x/tools/go/analysis"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "importdepth",
+	Doc: "reports packages with deep or wide module-internal import stacks\n\n" +
+		"Import depth is the longest chain of module-internal imports from a " +
+		"package down to a package that imports nothing else from the module. " +
+		"The transitive count is the number of distinct module-internal packages " +
+		"reached through imports. Standard library and third-party packages are " +
+		"ignored.",
+	Run:       run,
+	FactTypes: []analysis.Fact{new(Fact)},
+}
+
+var (
+	warnAt           int
+	failAt           int
+	transitiveWarnAt int
+	transitiveFailAt int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 5,
+		"import depth at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 8,
+		"import depth at or above this triggers a failure (red zone)")
+	Analyzer.Flags.IntVar(&transitiveWarnAt, "transitive-warn", 25,
+		"transitively imported module packages at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&transitiveFailAt, "transitive-fail", 50,
+		"transitively imported module packages at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+// Fact records a package's position in the module import graph so that
+// importers can extend it without walking the graph again.
+type Fact struct {
+	Chain []string // longest module-internal import chain below the package
+	Deps  []string // module-internal packages imported transitively, sorted
+}
+
+func (*Fact) AFact() {}
+
+func (f *Fact) String() string {
+	return fmt.Sprintf("importdepth(depth=%d, transitive=%d)", len(f.Chain), len(f.Deps))
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := defaults.Validate("importdepth"); err != nil {
+		return nil, err
+	}
+	transitiveDefaults := common.Thresholds{WarnAt: transitiveWarnAt, FailAt: transitiveFailAt}
+	if err := transitiveDefaults.Validate("importdepth-transitive"); err != nil {
+		return nil, err
+	}
+
+	fact := packageFact(pass)
+	pass.ExportPackageFact(fact)
+
+	if len(pass.Files) == 0 {
+		return nil, nil
+	}
+	pos := pass.Files[0].Name.Pos()
+	if common.IsExcluded(pass.Fset.Position(pos).Filename) {
+		return nil, nil
+	}
+	docs := make([]*ast.CommentGroup, 0, len(pass.Files))
+	for _, f := range pass.Files {
+		docs = append(docs, f.Doc)
+	}
+
+	thresholds := common.ParseDocOverrides("importdepth", defaults, docs...)
+	if zone := thresholds.Classify(len(fact.Chain)); zone != common.ZoneGreen {
+		pass.Report(analysis.Diagnostic{
+			Pos:      pos,
+			Category: zone.Category(),
+			Message: fmt.Sprintf(
+				"package %s has an import depth of %d via %s (warn: >=%d, fail: >=%d) [%s] "+
+					"(reduce by depending on interfaces declared in this package instead of deep concrete packages, or by merging thin intermediate packages)",
+				pass.Pkg.Name(), len(fact.Chain), chainString(pass, fact.Chain),
+				thresholds.WarnAt, thresholds.FailAt, zone.Category()),
+		})
+	}
+
+	transitiveThresholds := common.ParseDocOverrides("importdepth-transitive", transitiveDefaults, docs...)
+	if zone := transitiveThresholds.Classify(len(fact.Deps)); zone != common.ZoneGreen {
+		pass.Report(analysis.Diagnostic{
+			Pos:      pos,
+			Category: zone.Category(),
+			Message: fmt.Sprintf(
+				"package %s transitively imports %d module packages (warn: >=%d, fail: >=%d) [%s] "+
+					"(reduce by splitting the package so callers only pull in what they use)",
+				pass.Pkg.Name(), len(fact.Deps),
+				transitiveThresholds.WarnAt, transitiveThresholds.FailAt, zone.Category()),
+		})
+	}
+
+	return nil, nil
+}
+
+// packageFact extends the facts of the module packages the package imports.
+func packageFact(pass *analysis.Pass) *Fact {
+	fact := &Fact{}
+	deps := make(map[string]bool)
+	for _, imp := range pass.Pkg.Imports() {
+		if !common.IsModulePackage(pass.Module, imp.Path()) {
+			continue
+		}
+		deps[imp.Path()] = true
+
+		var impFact Fact
+		if !pass.ImportPackageFact(imp, &impFact) {
+			// Not analyzed (e.g. no Go files); treat it as a leaf.
+			impFact = Fact{}
+		}
+		for _, d := range impFact.Deps {
+			if common.IsModulePackage(pass.Module, d) {
+				deps[d] = true
+			}
+		}
+		// Ties go to the lexically smallest chain so output is stable.
+		chain := append([]string{imp.Path()}, moduleChain(pass.Module, impFact.Chain)...)
+		if len(chain) > len(fact.Chain) || (len(chain) == len(fact.Chain) && slices.Compare(chain, fact.Chain) < 0) {
+			fact.Chain = chain
+		}
+	}
+	for d := range deps {
+		fact.Deps = append(fact.Deps, d)
+	}
+	slices.Sort(fact.Deps)
+	return fact
+}
+
+// moduleChain returns the leading part of chain that stays within module.
+// An imported fact was computed against the imported package's own module,
+// which need not be this one, so its chain may leave the module.
+func moduleChain(module *analysis.Module, chain []string) []string {
+	for i, p := range chain {
+		if !common.IsModulePackage(module, p) {
+			return chain[:i]
+		}
+	}
+	return chain
+}
+
+// chainString renders the chain from the current package, with paths shown
+// relative to the module root when the module is known.
+func chainString(pass *analysis.Pass, chain []string) string {
+	parts := []string{pass.Pkg.Path()}
+	parts = append(parts, chain...)
+	if pass.Module != nil && pass.Module.Path != "" {
+		for i, p := range parts {
+			if rel, ok := strings.CutPrefix(p, pass.Module.Path+"/"); ok {
+				parts[i] = rel
+			}
+		}
+	}
+	return strings.Join(parts, " -> ")
+}
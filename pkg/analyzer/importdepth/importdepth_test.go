@@ -0,0 +1,30 @@
+package importdepth_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/importdepth"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestImportDepth(t *testing.T) {
+	testutil.SetFlag(t, importdepth.Analyzer, "warn", "3")
+	testutil.SetFlag(t, importdepth.Analyzer, "fail", "5")
+	testutil.SetFlag(t, importdepth.Analyzer, "transitive-warn", "4")
+	testutil.SetFlag(t, importdepth.Analyzer, "transitive-fail", "6")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, importdepth.Analyzer, "importdepth", "overridden")
+}
+
+func TestThirdParty(t *testing.T) {
+	testutil.SetFlag(t, importdepth.Analyzer, "warn", "1")
+	testutil.SetFlag(t, importdepth.Analyzer, "fail", "3")
+
+	// A directory with a go.mod is loaded as a module, with its third-party
+	// dependency replaced by a local copy.
+	testdata := filepath.Join(analysistest.TestData(), "thirdparty")
+	analysistest.Run(t, testdata, importdepth.Analyzer, "./...")
+}
@@ -0,0 +1,5 @@
+package a
+
+import "importdepth/b"
+
+var A = b.B
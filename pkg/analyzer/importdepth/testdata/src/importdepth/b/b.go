@@ -0,0 +1,5 @@
+package b
+
+import "importdepth/c"
+
+var B = c.C
@@ -0,0 +1,5 @@
+package c
+
+import "strings"
+
+var C = strings.ToUpper("c")
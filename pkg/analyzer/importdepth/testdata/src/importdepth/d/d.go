@@ -0,0 +1,8 @@
+package d
+
+import (
+	"importdepth/c"
+	"importdepth/e"
+)
+
+var D = c.C + e.E
@@ -0,0 +1,13 @@
+package importdepth // want "package importdepth has an import depth of 3 via importdepth -> importdepth/a -> importdepth/b -> importdepth/c \\(warn: >=3, fail: >=5\\) \\[warning\\] \\(reduce by depending on interfaces declared in this package instead of deep concrete packages, or by merging thin intermediate packages\\)" "package importdepth transitively imports 5 module packages \\(warn: >=4, fail: >=6\\) \\[warning\\] \\(reduce by splitting the package so callers only pull in what they use\\)" package:"importdepth\\(depth=3, transitive=5\\)"
+
+import (
+	"fmt"
+
+	"importdepth/a"
+	"importdepth/d"
+)
+
+// Standard library imports do not count.
+var _ = fmt.Sprint(a.A, d.D)
+
+const X = 1
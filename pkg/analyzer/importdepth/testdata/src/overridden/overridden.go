@@ -0,0 +1,12 @@
+/* want package:"importdepth\\(depth=4, transitive=6\\)" */
+
+// Package overridden is the application entry point and is expected to sit
+// on top of the whole module.
+//
+//complexity:importdepth:warn=10,fail=12
+//complexity:importdepth-transitive:warn=10,fail=12
+package overridden
+
+import "importdepth"
+
+var _ = importdepth.X
@@ -0,0 +1,10 @@
+package app // want "package app has an import depth of 1 via app -> core \\(warn: >=1, fail: >=3\\) \\[warning\\]" package:"importdepth\\(depth=1, transitive=1\\)"
+
+// app imports the third-party example.org/dep/a, whose chain a -> b -> c is
+// three packages deep, but only the module-internal core counts.
+import (
+	"example.com/app/core"
+	"example.org/dep/a"
+)
+
+var App = core.Core + a.A
@@ -0,0 +1,7 @@
+package core // want package:"importdepth\\(depth=0, transitive=0\\)"
+
+// core imports only a third-party package, so it is a leaf of the module
+// import graph.
+import "example.org/dep/b"
+
+var Core = b.B
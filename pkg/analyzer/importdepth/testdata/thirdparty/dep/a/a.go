@@ -0,0 +1,5 @@
+package a
+
+import "example.org/dep/b"
+
+var A = b.B
@@ -0,0 +1,5 @@
+package b
+
+import "example.org/dep/c"
+
+var B = c.C
@@ -0,0 +1,154 @@
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "mutation",
+	Doc: "reports functions that reassign or mutate many local variables\n\n" +
+		"Counts every write to a local variable after its declaration: plain " +
+		"and op-assignments, ++/--, redeclaration in :=, range assignments, and " +
+		"stores to fields, elements or pointees reached through a local " +
+		"(p.x = 1, s[i] = v, *p = v). Parameters and named results are locals. " +
+		"Initializing declarations are not counted. Variables are resolved with " +
+		"type information, so shadowed names are told apart.",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+var (
+	warnAt int
+	failAt int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 10,
+		"local variable mutation count at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 20,
+		"local variable mutation count at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := defaults.Validate("mutation"); err != nil {
+		return nil, err
+	}
+
+	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		funcDecl := n.(*ast.FuncDecl)
+		if funcDecl.Body == nil {
+			return
+		}
+		if common.IsExcluded(pass.Fset.Position(funcDecl.Pos()).Filename) {
+			return
+		}
+
+		funcName := common.FuncName(funcDecl)
+		thresholds := common.ParseOverrides(funcDecl, "mutation", defaults)
+
+		mutated := countMutations(pass, funcDecl)
+		total := 0
+		for _, count := range mutated {
+			total += count
+		}
+		zone := thresholds.Classify(total)
+
+		if zone == common.ZoneGreen {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      funcDecl.Pos(),
+			Category: zone.Category(),
+			Message: fmt.Sprintf(
+				"function %s has %d mutations of %d local variables (warn: >=%d, fail: >=%d) [%s] "+
+					"(reduce by assigning each value once, returning new values from helpers instead of updating them in place, or splitting the function)",
+				funcName, total, len(mutated), thresholds.WarnAt, thresholds.FailAt,
+				zone.Category()),
+		})
+	})
+
+	return nil, nil
+}
+
+// countMutations returns, per local variable of funcDecl, the number of
+// writes to it after its declaration. Closures are part of the body, so
+// writes to captured variables count toward the enclosing function.
+func countMutations(pass *analysis.Pass, funcDecl *ast.FuncDecl) map[*types.Var]int {
+	mutated := make(map[*types.Var]int)
+	record := func(lhs ast.Expr) {
+		if v := localRoot(pass, funcDecl, lhs); v != nil {
+			mutated[v]++
+		}
+	}
+
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range s.Lhs {
+				// := declares new variables; only the ones it reuses are written.
+				if id, ok := lhs.(*ast.Ident); ok && s.Tok == token.DEFINE && pass.TypesInfo.Defs[id] != nil {
+					continue
+				}
+				record(lhs)
+			}
+		case *ast.IncDecStmt:
+			record(s.X)
+		case *ast.RangeStmt:
+			if s.Tok == token.ASSIGN {
+				for _, e := range []ast.Expr{s.Key, s.Value} {
+					if e != nil {
+						record(e)
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	return mutated
+}
+
+// localRoot returns the local variable written by an assignment to lhs:
+// the variable itself, or the variable a field, element or pointee store is
+// reached through. It returns nil for package-level variables, blanks, and
+// stores through function results.
+func localRoot(pass *analysis.Pass, funcDecl *ast.FuncDecl, lhs ast.Expr) *types.Var {
+	for {
+		switch e := lhs.(type) {
+		case *ast.ParenExpr:
+			lhs = e.X
+		case *ast.StarExpr:
+			lhs = e.X
+		case *ast.IndexExpr:
+			lhs = e.X
+		case *ast.SelectorExpr:
+			if _, ok := pass.TypesInfo.Selections[e]; !ok {
+				return nil // package-qualified variable
+			}
+			lhs = e.X
+		case *ast.Ident:
+			v, ok := pass.TypesInfo.ObjectOf(e).(*types.Var)
+			if !ok || v.IsField() || v.Pos() < funcDecl.Pos() || v.Pos() >= funcDecl.End() {
+				return nil
+			}
+			return v
+		default:
+			return nil
+		}
+	}
+}
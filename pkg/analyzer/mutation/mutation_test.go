@@ -0,0 +1,17 @@
+package mutation_test
+
+import (
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/mutation"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestMutation(t *testing.T) {
+	testutil.SetFlag(t, mutation.Analyzer, "warn", "5")
+	testutil.SetFlag(t, mutation.Analyzer, "fail", "10")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, mutation.Analyzer, "mutation")
+}
@@ -0,0 +1,62 @@
+package mutation
+
+type point struct{ x, y int }
+
+var total int
+
+// quiet assigns each value once: no mutations.
+func quiet(a, b int) int {
+	sum := a + b
+	var p point
+	_ = p
+	return sum
+}
+
+func busy(xs []int, p *point) (n int, err error) { // want `function busy has 10 mutations of 8 local variables \(warn: >=5, fail: >=10\) \[error\] \(reduce by assigning each value once, returning new values from helpers instead of updating them in place, or splitting the function\)`
+	sum := 0
+	for _, x := range xs {
+		sum += x // op-assign
+		n++      // named result
+	}
+	p.x = sum    // field store through a parameter
+	xs[0] = 1    // element store
+	*p = point{} // pointee store
+	var i, j int
+	for i, j = range xs { // range assignment: 2
+	}
+	sum, k := i, j // sum is reused by :=; k is new
+	_ = k
+	total = sum // package-level: not counted
+	m := map[string]int{}
+	m["a"] = 1
+	err = nil
+	return n, err
+}
+
+func shadowed(x int) { // want `function shadowed has 5 mutations of 3 local variables \(warn: >=5, fail: >=10\) \[warning\]`
+	x = 1
+	{
+		x := 2 // a new variable, not a write to the parameter
+		x++
+		x--
+	}
+	f := func() {
+		x = 3 // closure writes count toward the enclosing function
+	}
+	f = nil
+	_ = f
+}
+
+//complexity:mutation:warn=20,fail=30 Accumulator loop.
+func overridden(xs []int) int {
+	a, b, c := 0, 0, 0
+	for _, x := range xs {
+		a += x
+		b += x
+		c += x
+		a++
+		b++
+		c++
+	}
+	return a + b + c
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
+	"reflect"
 
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
 	"golang.org/x/tools/go/analysis"
@@ -17,8 +19,9 @@ var Analyzer = &analysis.Analyzer{
 		"Nesting depth is incremented by control-flow constructs: " +
 		"if/else, for/range, switch, type switch, select, case/default, " +
 		"and anonymous function literals. Error guard clauses are exempt.",
-	Run:      run,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:        run,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	ResultType: reflect.TypeOf(Result(nil)),
 }
 
 var (
@@ -33,8 +36,16 @@ func init() {
 		"nesting depth at or above this triggers a failure (red zone)")
 	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
 		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+	common.RegisterFuncLitFlags(Analyzer)
+	common.RegisterIteratorFlag(Analyzer)
+	common.RegisterGuardsFlag(Analyzer)
 }
 
+// Result maps each analyzed function to its measure. Functions without a
+// body and functions in excluded files are absent, and so are function
+// literals measured as units of their own.
+type Result map[*ast.FuncDecl]common.Measure
+
 func run(pass *analysis.Pass) (any, error) {
 	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
@@ -42,6 +53,7 @@ func run(pass *analysis.Pass) (any, error) {
 		return nil, err
 	}
 
+	result := make(Result)
 	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
 
 	insp.Preorder(nodeFilter, func(n ast.Node) {
@@ -53,16 +65,25 @@ func run(pass *analysis.Pass) (any, error) {
 			return
 		}
 
-		funcName := common.FuncName(funcDecl)
 		thresholds := common.ParseOverrides(funcDecl, "nestdepth", defaults)
+		deepestPos, deepestDepth := newWalker(pass.TypesInfo, funcDecl).walkBody(funcDecl.Body, 0)
+		result[funcDecl] = check(pass, common.FuncName(funcDecl), deepestPos, deepestDepth, thresholds)
+	})
 
-		deepestPos, deepestDepth := walkBody(funcDecl.Body, 0)
-		zone := thresholds.Classify(deepestDepth)
+	for _, unit := range common.PassFuncLitUnits(pass) {
+		thresholds := common.ParseDocOverrides("nestdepth", defaults, unit.Docs...)
+		deepestPos, deepestDepth := newWalker(pass.TypesInfo, unit.Lit).walkBody(unit.Lit.Body, 0)
+		check(pass, unit.Name, deepestPos, deepestDepth, thresholds)
+	}
 
-		if zone == common.ZoneGreen {
-			return
-		}
+	return result, nil
+}
 
+// check classifies a function's deepest nesting and reports it outside the
+// green zone.
+func check(pass *analysis.Pass, funcName string, deepestPos token.Pos, deepestDepth int, thresholds common.Thresholds) common.Measure {
+	zone := thresholds.Classify(deepestDepth)
+	if zone != common.ZoneGreen {
 		pass.Report(analysis.Diagnostic{
 			Pos:      deepestPos,
 			Category: zone.Category(),
@@ -72,170 +93,173 @@ func run(pass *analysis.Pass) (any, error) {
 				funcName, deepestDepth, thresholds.WarnAt, thresholds.FailAt,
 				zone.Category()),
 		})
-	})
+	}
+	return common.Measure{Value: deepestDepth, Thresholds: thresholds, Zone: zone}
+}
 
-	return nil, nil
+// walker measures the nesting of one function. It carries the type
+// information error guards are resolved with and the if statements exempt
+// from nesting besides error guards.
+type walker struct {
+	info         *types.Info
+	resultGuards map[*ast.IfStmt]bool
+	loopGuards   map[*ast.IfStmt]bool
+	yieldGuards  map[*ast.IfStmt]bool
 }
 
-func walkBody(block *ast.BlockStmt, currentDepth int) (token.Pos, int) {
-	deepestDepth := currentDepth
-	deepestPos := block.Lbrace
+// newWalker returns a walker for fn, a function declaration or literal.
+func newWalker(info *types.Info, fn ast.Node) *walker {
+	w := &walker{
+		info:         info,
+		resultGuards: common.ResultGuards(info, fn),
+		loopGuards:   common.LoopGuards(fn),
+	}
+	if common.Iterators {
+		w.yieldGuards = common.YieldGuards(fn)
+	}
+	return w
+}
+
+// deepest tracks the deepest nesting found so far and where it occurs.
+type deepest struct {
+	pos   token.Pos
+	depth int
+}
 
+func (d *deepest) update(pos token.Pos, depth int) {
+	if depth > d.depth {
+		d.depth = depth
+		d.pos = pos
+	}
+}
+
+func (w *walker) walkBody(block *ast.BlockStmt, currentDepth int) (token.Pos, int) {
+	d := deepest{pos: block.Lbrace, depth: currentDepth}
 	for _, stmt := range block.List {
-		pos, depth := walkStmt(stmt, currentDepth)
-		if depth > deepestDepth {
-			deepestDepth = depth
-			deepestPos = pos
-		}
+		d.update(w.walkStmt(stmt, currentDepth))
 	}
-	return deepestPos, deepestDepth
+	return d.pos, d.depth
 }
 
-func walkStmt(stmt ast.Stmt, currentDepth int) (token.Pos, int) {
-	deepestDepth := currentDepth
-	deepestPos := stmt.Pos()
+// walkStmt returns the deepest nesting in stmt. Function literals in a
+// statement's header (if init and condition, for init/condition/post, range
+// expression, switch init and tag, case expressions, select comm) nest at the
+// depth of the statement itself, like literals in plain statements.
+//
+//complexity:cyclo:warn=20,fail=25 Dispatch on statement kind.
+func (w *walker) walkStmt(stmt ast.Stmt, currentDepth int) (token.Pos, int) {
+	d := deepest{pos: stmt.Pos(), depth: currentDepth}
 
 	switch s := stmt.(type) {
 	case *ast.IfStmt:
-		// Error guard clauses don't count as nesting.
-		if common.IsErrGuard(s) {
-			return deepestPos, deepestDepth
-		}
-
-		if s.Init != nil {
-			pos, depth := walkNodeForFuncLit(s.Init, currentDepth)
-			if depth > deepestDepth {
-				deepestDepth = depth
-				deepestPos = pos
-			}
-		}
+		// Closures in the header nest even when the if is an error guard.
+		d.update(w.walkFuncLits(currentDepth, s.Init, s.Cond))
 
-		newDepth := currentDepth + 1
-		pos, depth := walkBody(s.Body, newDepth)
-		if depth > deepestDepth {
-			deepestDepth = depth
-			deepestPos = pos
+		// Guard clauses and iterator yield exits don't count as nesting.
+		if common.IsErrGuard(w.info, s) || w.resultGuards[s] || w.loopGuards[s] || w.yieldGuards[s] {
+			return d.pos, d.depth
 		}
 
+		d.update(w.walkBody(s.Body, currentDepth+1))
 		if s.Else != nil {
-			pos, depth := walkElse(s.Else, currentDepth)
-			if depth > deepestDepth {
-				deepestDepth = depth
-				deepestPos = pos
-			}
+			d.update(w.walkElse(s.Else, currentDepth))
 		}
 
 	case *ast.ForStmt:
-		pos, depth := walkBody(s.Body, currentDepth+1)
-		if depth > deepestDepth {
-			deepestDepth = depth
-			deepestPos = pos
-		}
+		d.update(w.walkFuncLits(currentDepth, s.Init, s.Cond, s.Post))
+		d.update(w.walkBody(s.Body, currentDepth+1))
 
 	case *ast.RangeStmt:
-		pos, depth := walkBody(s.Body, currentDepth+1)
-		if depth > deepestDepth {
-			deepestDepth = depth
-			deepestPos = pos
-		}
+		d.update(w.walkFuncLits(currentDepth, s.X))
+		d.update(w.walkBody(s.Body, currentDepth+1))
 
 	case *ast.SwitchStmt:
-		pos, depth := walkBody(s.Body, currentDepth+1)
-		if depth > deepestDepth {
-			deepestDepth = depth
-			deepestPos = pos
-		}
+		d.update(w.walkFuncLits(currentDepth, s.Init, s.Tag))
+		d.update(w.walkBody(s.Body, currentDepth+1))
 
 	case *ast.TypeSwitchStmt:
-		pos, depth := walkBody(s.Body, currentDepth+1)
-		if depth > deepestDepth {
-			deepestDepth = depth
-			deepestPos = pos
-		}
+		d.update(w.walkFuncLits(currentDepth, s.Init, s.Assign))
+		d.update(w.walkBody(s.Body, currentDepth+1))
 
 	case *ast.SelectStmt:
-		pos, depth := walkBody(s.Body, currentDepth+1)
-		if depth > deepestDepth {
-			deepestDepth = depth
-			deepestPos = pos
-		}
+		d.update(w.walkBody(s.Body, currentDepth+1))
 
 	case *ast.CaseClause:
-		newDepth := currentDepth + 1
+		for _, expr := range s.List {
+			d.update(w.walkNodeForFuncLit(expr, currentDepth))
+		}
 		for _, bodyStmt := range s.Body {
-			pos, depth := walkStmt(bodyStmt, newDepth)
-			if depth > deepestDepth {
-				deepestDepth = depth
-				deepestPos = pos
-			}
+			d.update(w.walkStmt(bodyStmt, currentDepth+1))
 		}
 
 	case *ast.CommClause:
-		newDepth := currentDepth + 1
+		d.update(w.walkFuncLits(currentDepth, s.Comm))
 		for _, bodyStmt := range s.Body {
-			pos, depth := walkStmt(bodyStmt, newDepth)
-			if depth > deepestDepth {
-				deepestDepth = depth
-				deepestPos = pos
-			}
+			d.update(w.walkStmt(bodyStmt, currentDepth+1))
 		}
 
 	case *ast.BlockStmt:
 		for _, bodyStmt := range s.List {
-			pos, depth := walkStmt(bodyStmt, currentDepth)
-			if depth > deepestDepth {
-				deepestDepth = depth
-				deepestPos = pos
-			}
+			d.update(w.walkStmt(bodyStmt, currentDepth))
 		}
 
 	case *ast.LabeledStmt:
-		pos, depth := walkStmt(s.Stmt, currentDepth)
-		if depth > deepestDepth {
-			deepestDepth = depth
-			deepestPos = pos
-		}
+		d.update(w.walkStmt(s.Stmt, currentDepth))
 
 	default:
-		pos, depth := walkNodeForFuncLit(stmt, currentDepth)
-		if depth > deepestDepth {
-			deepestDepth = depth
-			deepestPos = pos
-		}
+		d.update(w.walkNodeForFuncLit(stmt, currentDepth))
 	}
 
-	return deepestPos, deepestDepth
+	return d.pos, d.depth
 }
 
-func walkElse(elseNode ast.Stmt, currentDepth int) (token.Pos, int) {
+func (w *walker) walkElse(elseNode ast.Stmt, currentDepth int) (token.Pos, int) {
 	switch e := elseNode.(type) {
 	case *ast.BlockStmt:
-		return walkBody(e, currentDepth+1)
+		return w.walkBody(e, currentDepth+1)
 	case *ast.IfStmt:
-		return walkStmt(e, currentDepth)
+		return w.walkStmt(e, currentDepth)
 	default:
 		return elseNode.Pos(), currentDepth
 	}
 }
 
-func walkNodeForFuncLit(node ast.Node, currentDepth int) (token.Pos, int) {
-	deepestPos := node.Pos()
-	deepestDepth := currentDepth
+// walkFuncLits returns the deepest nesting of function literals in the given
+// header parts of a statement; nil parts are skipped.
+func (w *walker) walkFuncLits(currentDepth int, nodes ...ast.Node) (token.Pos, int) {
+	d := deepest{depth: currentDepth}
+	for _, node := range nodes {
+		if node != nil {
+			d.update(w.walkNodeForFuncLit(node, currentDepth))
+		}
+	}
+	return d.pos, d.depth
+}
+
+func (w *walker) walkNodeForFuncLit(node ast.Node, currentDepth int) (token.Pos, int) {
+	d := deepest{pos: node.Pos(), depth: currentDepth}
 
 	ast.Inspect(node, func(n ast.Node) bool {
 		fl, ok := n.(*ast.FuncLit)
 		if !ok {
 			return true
 		}
-		if fl.Body != nil {
-			pos, depth := walkBody(fl.Body, currentDepth+1)
-			if depth > deepestDepth {
-				deepestDepth = depth
-				deepestPos = pos
-			}
+		// Detached literals are measured as units of their own.
+		if common.FuncLitsDetached() {
+			return false
+		}
+		if fl.Body == nil {
+			return false
+		}
+		// With -iterators, an iterator literal's body is the loop body of
+		// the range statement consuming it and adds no level of its own.
+		if _, ok := common.IteratorYield(fl); ok && common.Iterators {
+			d.update(w.walkBody(fl.Body, currentDepth))
+			return false
 		}
+		d.update(w.walkBody(fl.Body, currentDepth+1))
 		return false
 	})
 
-	return deepestPos, deepestDepth
+	return d.pos, d.depth
 }
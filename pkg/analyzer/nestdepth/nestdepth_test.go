@@ -3,6 +3,7 @@ package nestdepth_test
 import (
 	"testing"
 
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/nestdepth"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
@@ -11,3 +12,41 @@ func TestNestDepth(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, nestdepth.Analyzer, "nestdepth")
 }
+
+func TestFuncLits(t *testing.T) {
+	testutil.SetFlag(t, nestdepth.Analyzer, "warn", "2")
+	testutil.SetFlag(t, nestdepth.Analyzer, "fail", "3")
+	testutil.SetFlag(t, nestdepth.Analyzer, "funclits", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, nestdepth.Analyzer, "funclits")
+}
+
+func TestFuncLitsDetached(t *testing.T) {
+	testutil.SetFlag(t, nestdepth.Analyzer, "warn", "2")
+	testutil.SetFlag(t, nestdepth.Analyzer, "fail", "3")
+	testutil.SetFlag(t, nestdepth.Analyzer, "funclits", "true")
+	testutil.SetFlag(t, nestdepth.Analyzer, "funclits-detach", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, nestdepth.Analyzer, "detached")
+}
+
+func TestIterators(t *testing.T) {
+	testutil.SetFlag(t, nestdepth.Analyzer, "iterators", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, nestdepth.Analyzer, "iterators")
+}
+
+func TestGuardPatterns(t *testing.T) {
+	testutil.SetFlag(t, nestdepth.Analyzer, "guards", "commaok,nilcheck,logged,loop,panic")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, nestdepth.Analyzer, "guards")
+}
+
+func TestNamedResults(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, nestdepth.Analyzer, "namedresults")
+}
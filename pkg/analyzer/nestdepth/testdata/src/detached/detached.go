@@ -0,0 +1,37 @@
+package detached
+
+import (
+	"net/http"
+	"testing"
+)
+
+// handler is measured as handler.func1.
+var handler = func(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		for range 3 { // want `function handler.func1 has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+		}
+	}
+}
+
+// Routes leaves its handler's nesting to Routes.func1.
+func Routes(mux *http.ServeMux) {
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r != nil {
+			for range 3 { // want `function Routes.func1 has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+			}
+		}
+	}))
+}
+
+// Table's subtest carries its own override and no longer fails Table.
+func Table(t *testing.T) {
+	//complexity:nestdepth:warn=5,fail=7
+	t.Run("case", func(t *testing.T) {
+		if t != nil {
+			for i := range 4 {
+				if i > 0 {
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package guards
+
+// With the loop, panic and comma-ok guard patterns enabled, the guards in
+// Scan add no nesting: depth 1 (the for loop). Green zone.
+//
+//complexity:nestdepth:warn=2,fail=3
+func Scan(m map[string]int, keys []string) {
+	for _, k := range keys {
+		if k == "" {
+			continue
+		}
+		if k == "stop" {
+			break
+		}
+		v, ok := m[k]
+		if !ok {
+			return
+		}
+		if v < 0 {
+			panic("negative")
+		}
+	}
+}
+
+// Nested is still charged for an if that does more than exit:
+// for(1) -> if(2). Yellow zone.
+//
+//complexity:nestdepth:warn=2,fail=3
+func Nested(keys []string) int {
+	n := 0
+	for _, k := range keys {
+		if k == "" { // want `function Nested has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+			n++
+			continue
+		}
+	}
+	return n
+}
+
+// SwitchBreak is charged for its break guard, which ends the switch case
+// rather than the loop: for(1) -> switch(2) -> case(3) -> if(4). Yellow
+// zone.
+//
+//complexity:nestdepth:warn=4,fail=5
+func SwitchBreak(keys []string) int {
+	n := 0
+	for _, k := range keys {
+		switch {
+		case k != "":
+			if k == "stop" { // want `function SwitchBreak has a nesting depth of 4 \(warn: >=4, fail: >=5\) \[warning\]`
+				break
+			}
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,46 @@
+package iterators
+
+import "iter"
+
+// Numbers is depth 1 under -iterators: the iterator literal adds no level
+// and its yield exit is exempt. Green zone.
+//
+//complexity:nestdepth:warn=2,fail=3
+func Numbers(xs []int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, x := range xs {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Positive filters before yielding: range(1) -> if(2). Yellow zone.
+//
+//complexity:nestdepth:warn=2,fail=3
+func Positive(xs []int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for i, x := range xs {
+			if x > 0 { // want `function Positive has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+				if !yield(i, x) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Walk takes a callback of a different shape, so its literal still nests:
+// func(1) -> range(2) -> if(3). Red zone.
+//
+//complexity:nestdepth:warn=2,fail=3
+func Walk(xs []int) func(func(int) error) {
+	return func(visit func(int) error) {
+		for _, x := range xs {
+			if visit(x) != nil { // want `function Walk has a nesting depth of 3 \(warn: >=2, fail: >=3\) \[error\]`
+				return
+			}
+		}
+	}
+}
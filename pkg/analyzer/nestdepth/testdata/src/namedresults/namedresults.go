@@ -0,0 +1,45 @@
+package namedresults
+
+func parse(string) (int, error) { return 0, nil }
+
+// Sum's loop body guard returns through the named results and adds no
+// level: depth 1. Green zone.
+//
+//complexity:nestdepth:warn=2,fail=3
+func Sum(ss []string) (total int, err error) {
+	for _, s := range ss {
+		n, err := parse(s)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+//complexity:nestdepth:warn=2,fail=3
+func Bare(ss []string) (total int, err error) {
+	for _, s := range ss {
+		var n int
+		if n, err = parse(s); err != nil {
+			return
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// Local checks a loop-local error with a bare return, which returns the
+// named err instead: depth 2. Yellow zone.
+//
+//complexity:nestdepth:warn=2,fail=3
+func Local(ss []string) (total int, err error) {
+	for _, s := range ss {
+		n, e := parse(s)
+		if e != nil { // want `function Local has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+			return
+		}
+		total += n
+	}
+	return total, nil
+}
@@ -0,0 +1,35 @@
+package nestdepth
+
+// Error guards are resolved with type information.
+
+var ErrSkipped error = &skipError{}
+
+type skipError struct{}
+
+func (*skipError) Error() string { return "skipped" }
+
+// SentinelGuard returns a sentinel from its guard: depth 1 (the for loop).
+// Green zone.
+//
+//complexity:nestdepth:warn=2,fail=3
+func SentinelGuard() error {
+	for i := 0; i < 3; i++ {
+		if err := doSomething(); err != nil {
+			return ErrSkipped
+		}
+	}
+	return nil
+}
+
+// PointerCheck is not an error guard, p is not an error: for(1) -> if(2).
+// Yellow zone.
+//
+//complexity:nestdepth:warn=2,fail=3
+func PointerCheck(ps []*int) (*int, any) {
+	for _, p := range ps {
+		if p != nil { // want `function PointerCheck has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+			return nil, p
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,147 @@
+package nestdepth
+
+import (
+	"iter"
+	"slices"
+)
+
+// Closures in statement headers nest like closures in plain statements:
+// the literal adds a level and its body nests below it. Each function below
+// reaches depth 2 inside a closure in a different header position.
+
+//complexity:nestdepth:warn=2,fail=3
+func ClosureInIfCond() {
+	if func() bool {
+		if true { // want `function ClosureInIfCond has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+		}
+		return true
+	}() {
+	}
+}
+
+//complexity:nestdepth:warn=2,fail=3
+func ClosureInIfInit() {
+	if ok := func() bool {
+		if true { // want `function ClosureInIfInit has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+		}
+		return true
+	}(); ok {
+	}
+}
+
+// ClosureInErrGuardInit: the guard itself is exempt, its header is not.
+//
+//complexity:nestdepth:warn=2,fail=3
+func ClosureInErrGuardInit() error {
+	if err := run(func() {
+		if true { // want `function ClosureInErrGuardInit has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+		}
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+//complexity:nestdepth:warn=2,fail=3
+func ClosureInForInit() {
+	for i := func() int {
+		if true { // want `function ClosureInForInit has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+		}
+		return 0
+	}(); i < 3; i++ {
+	}
+}
+
+//complexity:nestdepth:warn=2,fail=3
+func ClosureInForCond() {
+	for i := 0; func() bool {
+		if true { // want `function ClosureInForCond has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+		}
+		return i < 3
+	}(); i++ {
+	}
+}
+
+//complexity:nestdepth:warn=2,fail=3
+func ClosureInForPost() {
+	for i := 0; i < 3; i = func() int {
+		if true { // want `function ClosureInForPost has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+		}
+		return i + 1
+	}() {
+	}
+}
+
+//complexity:nestdepth:warn=2,fail=3
+func ClosureInRangeExpr() {
+	for range slices.Collect(iter.Seq[int](func(yield func(int) bool) {
+		if !yield(1) { // want `function ClosureInRangeExpr has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+			return
+		}
+	})) {
+	}
+}
+
+//complexity:nestdepth:warn=2,fail=3
+func ClosureInSwitchInit() {
+	switch x := func() int {
+		if true { // want `function ClosureInSwitchInit has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+		}
+		return 0
+	}(); x {
+	}
+}
+
+//complexity:nestdepth:warn=2,fail=3
+func ClosureInSwitchTag() {
+	switch func() int {
+		if true { // want `function ClosureInSwitchTag has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+		}
+		return 0
+	}() {
+	}
+}
+
+//complexity:nestdepth:warn=2,fail=3
+func ClosureInTypeSwitchAssign() {
+	switch func() any {
+		if true { // want `function ClosureInTypeSwitchAssign has a nesting depth of 2 \(warn: >=2, fail: >=3\) \[warning\]`
+		}
+		return nil
+	}().(type) {
+	}
+}
+
+// ClosureInCaseExpr: the literal sits in the case clause, one level below
+// the switch.
+//
+//complexity:nestdepth:warn=3,fail=4
+func ClosureInCaseExpr(x int) {
+	switch x {
+	case func() int {
+		if true { // want `function ClosureInCaseExpr has a nesting depth of 3 \(warn: >=3, fail: >=4\) \[warning\]`
+		}
+		return 0
+	}():
+	}
+}
+
+// ClosureInSelectComm: the literal sits in the comm clause, one level below
+// the select.
+//
+//complexity:nestdepth:warn=3,fail=4
+func ClosureInSelectComm() {
+	select {
+	case <-func() chan int {
+		if true { // want `function ClosureInSelectComm has a nesting depth of 3 \(warn: >=3, fail: >=4\) \[warning\]`
+		}
+		return nil
+	}():
+	default:
+	}
+}
+
+func run(f func()) error {
+	f()
+	return nil
+}
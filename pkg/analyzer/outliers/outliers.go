@@ -0,0 +1,300 @@
+package outliers
+
+import (
+	"fmt"
+	"go/ast"
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/cyclo"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/fanout"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/nestdepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/params"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "outliers",
+	Doc: "reports functions whose metrics are outliers within their package or module\n\n" +
+		"Compares each function's nestdepth, cyclo, params and fanout values with " +
+		"the other functions in its package (or, with -scope=module in the " +
+		"standalone command, the whole module): by percentile rank, by standard " +
+		"deviations above the mean, or both. Values below -floor percent of the " +
+		"metric's warn threshold are never outliers. Off unless -enable is set; " +
+		"outliers are warnings unless -errors is set too.",
+	Run: run,
+	Requires: []*analysis.Analyzer{
+		nestdepth.Analyzer, cyclo.Analyzer, params.Analyzer, fanout.Analyzer,
+	},
+	ResultType: reflect.TypeOf((*Result)(nil)),
+}
+
+var (
+	enable      bool
+	redZone     bool
+	warnAt      int
+	failAt      int
+	sigmaWarnAt int
+	sigmaFailAt int
+	mode        string
+	scope       string
+	minFuncs    int
+	floor       int
+	replace     bool
+)
+
+func init() {
+	Analyzer.Flags.BoolVar(&enable, "enable", false,
+		"report outliers; the analyzer is off by default")
+	Analyzer.Flags.BoolVar(&redZone, "errors", false,
+		"report outliers at or above the fail thresholds as failures (red zone) instead of warnings")
+	Analyzer.Flags.IntVar(&warnAt, "warn", 95,
+		"percentile rank at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 99,
+		"percentile rank at or above this triggers a failure (red zone) with -errors")
+	Analyzer.Flags.IntVar(&sigmaWarnAt, "sigma-warn", 2,
+		"standard deviations above the mean at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&sigmaFailAt, "sigma-fail", 3,
+		"standard deviations above the mean at or above this triggers a failure (red zone) with -errors")
+	Analyzer.Flags.StringVar(&mode, "mode", "percentile",
+		"outlier test: percentile, stddev or both")
+	Analyzer.Flags.StringVar(&scope, "scope", "package",
+		"distribution functions are compared within: package, or module (standalone command only; package elsewhere)")
+	Analyzer.Flags.IntVar(&minFuncs, "min-funcs", 10,
+		"smallest number of functions a distribution needs before outliers are reported")
+	Analyzer.Flags.IntVar(&floor, "floor", 50,
+		"values below this percentage of the metric's warn threshold are never outliers")
+	Analyzer.Flags.BoolVar(&replace, "replace", false,
+		"drop the absolute nestdepth, cyclo, params and fanout diagnostics (standalone command and golangci-lint only)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+// ModuleReport declares that the driver calls ReportModule over the finished
+// graph, as the standalone command does. Only then does -scope=module defer
+// reporting to ReportModule; other drivers see one package at a time, so
+// module scope falls back to package scope under them.
+var ModuleReport bool
+
+// moduleScope reports whether functions are compared across the module.
+func moduleScope() bool {
+	return scope == "module" && ModuleReport
+}
+
+// metrics are the analyzers whose measures are compared, in report order.
+var metrics = []*analysis.Analyzer{nestdepth.Analyzer, cyclo.Analyzer, params.Analyzer, fanout.Analyzer}
+
+// Sample is one function's measure of one metric.
+type Sample struct {
+	Metric  string
+	Func    *ast.FuncDecl
+	Measure common.Measure
+}
+
+// Result holds the samples of a package, pooled by ReportModule when
+// functions are compared across the module. It is empty unless -enable is
+// set.
+type Result struct {
+	Samples []Sample
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if err := validate(); err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	if !enable {
+		return result, nil
+	}
+	for _, a := range metrics {
+		for funcDecl, m := range measures(pass, a) {
+			result.Samples = append(result.Samples, Sample{Metric: a.Name, Func: funcDecl, Measure: m})
+		}
+	}
+	// Map order is random; report in source order.
+	slices.SortStableFunc(result.Samples, func(a, b Sample) int {
+		return int(a.Func.Pos() - b.Func.Pos())
+	})
+
+	if !moduleScope() {
+		for _, o := range diagnose(result.Samples, "package "+pass.Pkg.Name()) {
+			pass.Report(o.diagnostic)
+		}
+	}
+	return result, nil
+}
+
+// measures returns the per-function result of one of the metrics analyzers.
+func measures(pass *analysis.Pass, a *analysis.Analyzer) map[*ast.FuncDecl]common.Measure {
+	switch a {
+	case nestdepth.Analyzer:
+		return pass.ResultOf[a].(nestdepth.Result)
+	case cyclo.Analyzer:
+		return pass.ResultOf[a].(cyclo.Result)
+	case params.Analyzer:
+		return pass.ResultOf[a].(params.Result)
+	default:
+		return pass.ResultOf[a].(fanout.Result)
+	}
+}
+
+func validate() error {
+	if err := (common.Thresholds{WarnAt: warnAt, FailAt: failAt}).Validate("outliers"); err != nil {
+		return err
+	}
+	if err := (common.Thresholds{WarnAt: sigmaWarnAt, FailAt: sigmaFailAt}).Validate("outliers-sigma"); err != nil {
+		return err
+	}
+	switch mode {
+	case "percentile", "stddev", "both":
+	default:
+		return fmt.Errorf("outliers: mode must be percentile, stddev or both, got %q", mode)
+	}
+	switch scope {
+	case "package", "module":
+	default:
+		return fmt.Errorf("outliers: scope must be package or module, got %q", scope)
+	}
+	return nil
+}
+
+// ReportModule finishes the outliers analysis over a completed graph. With
+// -scope=module it compares every function in the root packages with each
+// other and appends the diagnostics to the outliers action of the function's
+// package. With -replace it drops the absolute nestdepth, cyclo, params and
+// fanout diagnostics of the root packages. It is a no-op when the outliers
+// analyzer is not enabled or not part of graph.
+func ReportModule(graph *checker.Graph) {
+	roots := common.RootActions(graph, Analyzer)
+	if !enable || len(roots) == 0 {
+		return
+	}
+
+	if moduleScope() {
+		var samples []Sample
+		owner := make(map[*ast.FuncDecl]*checker.Action)
+		for _, act := range roots {
+			for _, s := range act.Result.(*Result).Samples {
+				samples = append(samples, s)
+				owner[s.Func] = act
+			}
+		}
+		for _, o := range diagnose(samples, "the module") {
+			act := owner[o.funcDecl]
+			act.Diagnostics = append(act.Diagnostics, o.diagnostic)
+		}
+	}
+
+	if replace {
+		for act := range graph.All() {
+			if act.IsRoot && slices.Contains(metrics, act.Analyzer) {
+				act.Diagnostics = nil
+			}
+		}
+	}
+}
+
+// outlier is a diagnostic together with the function it is reported on.
+type outlier struct {
+	funcDecl   *ast.FuncDecl
+	diagnostic analysis.Diagnostic
+}
+
+// diagnose returns the outlier diagnostics of samples, compared per metric.
+// where names the population in messages.
+func diagnose(samples []Sample, where string) []outlier {
+	var found []outlier
+	for _, a := range metrics {
+		var group []Sample
+		for _, s := range samples {
+			if s.Metric == a.Name {
+				group = append(group, s)
+			}
+		}
+		if len(group) < minFuncs {
+			continue
+		}
+		values := make([]int, len(group))
+		for i, s := range group {
+			values[i] = s.Measure.Value
+		}
+		dist := newDistribution(values)
+		for _, s := range group {
+			if aboveFloor(s.Measure) {
+				for _, d := range check(s, dist, where) {
+					found = append(found, outlier{funcDecl: s.Func, diagnostic: d})
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(found, func(a, b outlier) int {
+		return int(a.diagnostic.Pos - b.diagnostic.Pos)
+	})
+	return found
+}
+
+func aboveFloor(m common.Measure) bool {
+	return m.Value > 0 && m.Value*100 >= floor*m.Thresholds.WarnAt
+}
+
+// check returns the diagnostics for one sample under the configured mode.
+func check(s Sample, dist distribution, where string) []analysis.Diagnostic {
+	var diags []analysis.Diagnostic
+	name := common.FuncName(s.Func)
+	advice := fmt.Sprintf("it is among the highest %s values in %s; start refactoring here", s.Metric, where)
+
+	if mode != "stddev" {
+		thresholds := common.ParseOverrides(s.Func, "outliers", common.Thresholds{WarnAt: warnAt, FailAt: failAt})
+		rank := dist.percentileRank(s.Measure.Value)
+		if zone := classify(thresholds, rank); zone != common.ZoneGreen {
+			diags = append(diags, diagnostic(s.Func, zone, fmt.Sprintf(
+				"function %s has %s %d, at percentile %d of %s (%s) [%s] (%s)",
+				name, s.Metric, s.Measure.Value, rank, where,
+				limits(thresholds), zone.Category(), advice)))
+		}
+	}
+
+	if mode != "percentile" {
+		thresholds := common.ParseOverrides(s.Func, "outliers-sigma", common.Thresholds{WarnAt: sigmaWarnAt, FailAt: sigmaFailAt})
+		sigmas := dist.sigmas(s.Measure.Value)
+		// Flooring keeps "at or above" exact for integer thresholds.
+		if zone := classify(thresholds, int(math.Floor(sigmas))); zone != common.ZoneGreen {
+			diags = append(diags, diagnostic(s.Func, zone, fmt.Sprintf(
+				"function %s has %s %d, %.1f standard deviations above the mean of %.1f in %s (%s) [%s] (%s)",
+				name, s.Metric, s.Measure.Value, sigmas, dist.mean, where,
+				limits(thresholds), zone.Category(), advice)))
+		}
+	}
+	return diags
+}
+
+// classify returns the zone of value, at most yellow unless -errors is set:
+// an outlier is relative to its neighbors, so by default it only warns.
+func classify(t common.Thresholds, value int) common.Zone {
+	zone := t.Classify(value)
+	if zone == common.ZoneRed && !redZone {
+		return common.ZoneYellow
+	}
+	return zone
+}
+
+// limits describes the thresholds in messages; the fail threshold only
+// applies with -errors.
+func limits(t common.Thresholds) string {
+	if !redZone {
+		return fmt.Sprintf("warn: >=%d", t.WarnAt)
+	}
+	return fmt.Sprintf("warn: >=%d, fail: >=%d", t.WarnAt, t.FailAt)
+}
+
+func diagnostic(funcDecl *ast.FuncDecl, zone common.Zone, message string) analysis.Diagnostic {
+	return analysis.Diagnostic{
+		Pos:      funcDecl.Pos(),
+		Category: zone.Category(),
+		Message:  message,
+	}
+}
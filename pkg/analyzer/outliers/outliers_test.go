@@ -0,0 +1,129 @@
+package outliers_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/cyclo"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/outliers"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestPercentile(t *testing.T) {
+	testutil.SetFlag(t, outliers.Analyzer, "enable", "true")
+	testutil.SetFlag(t, outliers.Analyzer, "errors", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, outliers.Analyzer, "outliers")
+}
+
+func TestDefaults(t *testing.T) {
+	testutil.SetFlag(t, outliers.Analyzer, "enable", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, outliers.Analyzer, "defaults")
+}
+
+func TestDisabled(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, outliers.Analyzer, "disabled")
+}
+
+func TestStddev(t *testing.T) {
+	testutil.SetFlag(t, outliers.Analyzer, "enable", "true")
+	testutil.SetFlag(t, outliers.Analyzer, "errors", "true")
+	testutil.SetFlag(t, outliers.Analyzer, "mode", "stddev")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, outliers.Analyzer, "sigma")
+}
+
+func TestModuleScopeFallback(t *testing.T) {
+	// Without a driver calling ReportModule, module scope reports per package.
+	testutil.SetFlag(t, outliers.Analyzer, "enable", "true")
+	testutil.SetFlag(t, outliers.Analyzer, "errors", "true")
+	testutil.SetFlag(t, outliers.Analyzer, "scope", "module")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, outliers.Analyzer, "outliers")
+}
+
+func TestReportModule(t *testing.T) {
+	outliers.ModuleReport = true
+	t.Cleanup(func() { outliers.ModuleReport = false })
+	testutil.SetFlag(t, outliers.Analyzer, "enable", "true")
+	// Each package alone is below min-funcs; together they are not.
+	testutil.SetFlag(t, outliers.Analyzer, "scope", "module")
+	// cyclo reports the outlier on its own too, until -replace drops it.
+	testutil.SetFlag(t, cyclo.Analyzer, "warn", "5")
+
+	want := []string{
+		"cyclo: warning: function Branchy has cyclomatic complexity of 6",
+		"outliers: warning: function Branchy has cyclo 6, at percentile 100 of the module (warn: >=95) [warning]",
+	}
+	if got := analyzeModule(t); !equalPrefixes(got, want) {
+		t.Errorf("diagnostics = %q, want prefixes %q", got, want)
+	}
+
+	testutil.SetFlag(t, outliers.Analyzer, "replace", "true")
+	want = want[1:]
+	if got := analyzeModule(t); !equalPrefixes(got, want) {
+		t.Errorf("with -replace, diagnostics = %q, want prefixes %q", got, want)
+	}
+
+	// -replace belongs to the outliers mode, so it does nothing while the
+	// mode is off.
+	testutil.SetFlag(t, outliers.Analyzer, "enable", "false")
+	want = []string{"cyclo: warning: function Branchy has cyclomatic complexity of 6"}
+	if got := analyzeModule(t); !equalPrefixes(got, want) {
+		t.Errorf("without -enable, diagnostics = %q, want prefixes %q", got, want)
+	}
+}
+
+func analyzeModule(t *testing.T) []string {
+	t.Helper()
+
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Dir: "testdata/module"}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		t.Fatalf("%d package errors", n)
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{cyclo.Analyzer, outliers.Analyzer}, pkgs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outliers.ReportModule(graph)
+
+	var got []string
+	for act := range graph.All() {
+		if !act.IsRoot {
+			continue
+		}
+		for _, d := range act.Diagnostics {
+			got = append(got, act.Analyzer.Name+": "+d.Category+": "+d.Message)
+		}
+	}
+	sort.Strings(got)
+	return got
+}
+
+func equalPrefixes(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i], want[i]) {
+			return false
+		}
+	}
+	return true
+}
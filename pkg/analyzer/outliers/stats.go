@@ -0,0 +1,51 @@
+package outliers
+
+import (
+	"math"
+	"slices"
+)
+
+// distribution summarizes the values of one metric over a set of functions.
+type distribution struct {
+	sorted []int
+	mean   float64
+	stddev float64
+}
+
+func newDistribution(values []int) distribution {
+	d := distribution{sorted: slices.Sorted(slices.Values(values))}
+	if len(values) == 0 {
+		return d
+	}
+	sum := 0
+	for _, v := range values {
+		sum += v
+	}
+	d.mean = float64(sum) / float64(len(values))
+	variance := 0.0
+	for _, v := range values {
+		variance += (float64(v) - d.mean) * (float64(v) - d.mean)
+	}
+	d.stddev = math.Sqrt(variance / float64(len(values)))
+	return d
+}
+
+// percentileRank returns the percentage of the other values that are
+// strictly below v, so a unique maximum ranks 100 and a tie for the minimum
+// ranks 0.
+func (d distribution) percentileRank(v int) int {
+	if len(d.sorted) < 2 {
+		return 0
+	}
+	below, _ := slices.BinarySearch(d.sorted, v)
+	return below * 100 / (len(d.sorted) - 1)
+}
+
+// sigmas returns how many standard deviations v lies above the mean, or 0
+// when v is not above it or all values are equal.
+func (d distribution) sigmas(v int) float64 {
+	if d.stddev == 0 || float64(v) <= d.mean {
+		return 0
+	}
+	return (float64(v) - d.mean) / d.stddev
+}
@@ -0,0 +1,45 @@
+package outliers
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistribution(t *testing.T) {
+	d := newDistribution([]int{1, 1, 2, 3, 3, 10})
+
+	tests := []struct {
+		value    int
+		wantRank int
+	}{
+		{value: 1, wantRank: 0},
+		{value: 2, wantRank: 40},
+		{value: 3, wantRank: 60},
+		{value: 10, wantRank: 100},
+	}
+	for _, tt := range tests {
+		if got := d.percentileRank(tt.value); got != tt.wantRank {
+			t.Errorf("percentileRank(%d) = %d, want %d", tt.value, got, tt.wantRank)
+		}
+	}
+
+	if d.mean != 20.0/6 {
+		t.Errorf("mean = %v, want %v", d.mean, 20.0/6)
+	}
+	if got, want := d.sigmas(10), (10-20.0/6)/d.stddev; math.Abs(got-want) > 1e-9 {
+		t.Errorf("sigmas(10) = %v, want %v", got, want)
+	}
+	if got := d.sigmas(2); got != 0 {
+		t.Errorf("sigmas(2) = %v, want 0 below the mean", got)
+	}
+}
+
+func TestDistributionDegenerate(t *testing.T) {
+	d := newDistribution([]int{4, 4, 4})
+	if got := d.sigmas(4); got != 0 {
+		t.Errorf("sigmas with zero stddev = %v, want 0", got)
+	}
+	if got := newDistribution([]int{7}).percentileRank(7); got != 0 {
+		t.Errorf("percentileRank of a single value = %d, want 0", got)
+	}
+}
@@ -0,0 +1,20 @@
+package branchy
+
+func F0() {}
+func F1() {}
+func F2() {}
+func F3() {}
+func F4() {}
+
+func Branchy(x int) {
+	if x == 1 {
+	}
+	if x == 2 {
+	}
+	if x == 3 {
+	}
+	if x == 4 {
+	}
+	if x == 5 {
+	}
+}
@@ -0,0 +1,8 @@
+package small
+
+func F0() {}
+func F1() {}
+func F2() {}
+func F3() {}
+func F4() {}
+func F5() {}
@@ -0,0 +1,18 @@
+package defaults
+
+// Nine trivial functions set the baseline.
+func f0() {}
+func f1() {}
+func f2() {}
+func f3() {}
+func f4() {}
+func f5() {}
+func f6() {}
+func f7() {}
+func f8() {}
+
+// worst ranks 100, past the fail threshold, but without -errors an outlier
+// only warns.
+func worst(a, b, c, d int) { // want `function worst has params 4, at percentile 100 of package defaults \(warn: >=95\) \[warning\]`
+	_, _, _, _ = a, b, c, d
+}
@@ -0,0 +1,17 @@
+package disabled
+
+// Nine trivial functions set the baseline.
+func f0() {}
+func f1() {}
+func f2() {}
+func f3() {}
+func f4() {}
+func f5() {}
+func f6() {}
+func f7() {}
+func f8() {}
+
+// worst would rank 100, but outliers are off without -enable.
+func worst(a, b, c, d int) {
+	_, _, _, _ = a, b, c, d
+}
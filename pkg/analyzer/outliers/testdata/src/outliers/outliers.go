@@ -0,0 +1,33 @@
+package outliers
+
+// Nine trivial functions set the baseline.
+func f0() {}
+func f1() {}
+func f2() {}
+func f3() {}
+func f4() {}
+func f5() {}
+func f6() {}
+func f7() {}
+func f8() {}
+
+// second has the most parameters in the package.
+func second(a, b, c, d int) { // want `function second has params 4, at percentile 100 of package outliers \(warn: >=95, fail: >=99\) \[error\] \(it is among the highest params values in package outliers; start refactoring here\)`
+	_, _, _, _ = a, b, c, d
+}
+
+// worst has the highest cyclo; its override keeps it a warning.
+//
+//complexity:outliers:warn=100,fail=101
+func worst(a, b, c int) { // want `function worst has cyclo 6, at percentile 100 of package outliers \(warn: >=100, fail: >=101\) \[warning\]`
+	if a > 0 {
+	}
+	if a > 1 {
+	}
+	if b > 0 {
+	}
+	if b > 1 {
+	}
+	if c > 0 {
+	}
+}
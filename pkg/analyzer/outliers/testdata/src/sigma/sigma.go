@@ -0,0 +1,30 @@
+package sigma
+
+// Ten trivial functions set the baseline.
+func f0() {}
+func f1() {}
+func f2() {}
+func f3() {}
+func f4() {}
+func f5() {}
+func f6() {}
+func f7() {}
+func f8() {}
+func f9() {}
+
+func worst(x int) { // want `function worst has cyclo 8, 3.2 standard deviations above the mean of 1.6 in package sigma \(warn: >=2, fail: >=3\) \[error\] \(it is among the highest cyclo values in package sigma; start refactoring here\)`
+	if x == 1 {
+	}
+	if x == 2 {
+	}
+	if x == 3 {
+	}
+	if x == 4 {
+	}
+	if x == 5 {
+	}
+	if x == 6 {
+	}
+	if x == 7 {
+	}
+}
@@ -0,0 +1,55 @@
+package params
+
+import (
+	"strings"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+)
+
+// exemption is the parameter exemption selected by -ctx-names and -exempt.
+// Both flags parse into it when set, so an invalid value is rejected with
+// the flags, and the params pass and CountParams share one parsed policy.
+var exemption common.ParamExemption
+
+// ctxNamesValue is the -ctx-names flag: ctx, or any.
+type ctxNamesValue struct{ e *common.ParamExemption }
+
+// String returns the flag value.
+func (v ctxNamesValue) String() string {
+	if v.e != nil && v.e.AnyContextName {
+		return "any"
+	}
+	return "ctx"
+}
+
+// Set parses a -ctx-names flag value, keeping the exempt types.
+func (v ctxNamesValue) Set(value string) error {
+	parsed, err := common.ParseParamExemption(value, "")
+	if err != nil {
+		return err
+	}
+	v.e.AnyContextName = parsed.AnyContextName
+	return nil
+}
+
+// exemptTypesValue is the -exempt flag: a comma-separated list of presets
+// and qualified type names.
+type exemptTypesValue struct{ e *common.ParamExemption }
+
+// String returns the flag value, with presets expanded.
+func (v exemptTypesValue) String() string {
+	if v.e == nil {
+		return ""
+	}
+	return strings.Join(v.e.Types, ",")
+}
+
+// Set parses an -exempt flag value, replacing the exempt types.
+func (v exemptTypesValue) Set(value string) error {
+	parsed, err := common.ParseParamExemption("ctx", value)
+	if err != nil {
+		return err
+	}
+	v.e.Types = parsed.Types
+	return nil
+}
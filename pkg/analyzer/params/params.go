@@ -3,6 +3,9 @@ package params
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
 
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
 	"golang.org/x/tools/go/analysis"
@@ -14,15 +17,22 @@ var Analyzer = &analysis.Analyzer{
 	Name: "params",
 	Doc: "reports functions with too many parameters\n\n" +
 		"Counts the number of parameters in a function signature, " +
-		"properly handling grouped parameters like func(a, b int). " +
-		"A leading ctx context.Context parameter is not counted.",
-	Run:      run,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+		"properly handling grouped parameters like func(a, b int). Interface " +
+		"methods and named func types are checked as well. " +
+		"A ctx context.Context parameter is not counted; -ctx-names=any " +
+		"exempts context parameters of any name and -exempt adds other " +
+		"boilerplate types such as *testing.T or *http.Request. With -weighted, " +
+		"bool flags, func and interface{}/any parameters and parameters of " +
+		"the same type as the one before count extra.",
+	Run:        run,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	ResultType: reflect.TypeOf(Result(nil)),
 }
 
 var (
-	warnAt int
-	failAt int
+	warnAt   int
+	failAt   int
+	weighted bool
 )
 
 func init() {
@@ -30,72 +40,135 @@ func init() {
 		"parameter count at or above this triggers a warning (yellow zone)")
 	Analyzer.Flags.IntVar(&failAt, "fail", 7,
 		"parameter count at or above this triggers a failure (red zone)")
+	Analyzer.Flags.Var(ctxNamesValue{&exemption}, "ctx-names",
+		"names of exempt context.Context parameters: ctx, or any")
+	Analyzer.Flags.Var(exemptTypesValue{&exemption}, "exempt",
+		"comma-separated parameter types exempt under any name: testing, http, or qualified types (e.g. *example.com/log.Logger)")
+	Analyzer.Flags.BoolVar(&weighted, "weighted", false,
+		"weigh parameters by type: bool flags, func and interface{}/any parameters and parameters of the same type as the previous one count extra")
 	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
 		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+	common.RegisterFuncLitFlags(Analyzer)
 }
 
+// Result maps each analyzed function to its measure. Functions in excluded
+// files are absent, and so are function literals measured as units of their
+// own.
+type Result map[*ast.FuncDecl]common.Measure
+
 func run(pass *analysis.Pass) (any, error) {
 	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
 	if err := defaults.Validate("params"); err != nil {
 		return nil, err
 	}
+	c := &checker{pass: pass, defaults: defaults, exemption: exemption}
 
-	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
+	result := make(Result)
+	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil), (*ast.GenDecl)(nil)}
 
 	insp.Preorder(nodeFilter, func(n ast.Node) {
-		funcDecl := n.(*ast.FuncDecl)
-		if funcDecl.Type == nil {
+		if common.IsExcluded(pass.Fset.Position(n.Pos()).Filename) {
 			return
 		}
-		if common.IsExcluded(pass.Fset.Position(funcDecl.Pos()).Filename) {
-			return
+		switch d := n.(type) {
+		case *ast.FuncDecl:
+			thresholds := common.ParseOverrides(d, "params", defaults)
+			result[d] = c.check(d, "function "+common.FuncName(d), d.Type, thresholds)
+		case *ast.GenDecl:
+			c.typeDecl(d)
 		}
+	})
+
+	for _, unit := range common.PassFuncLitUnits(pass) {
+		thresholds := common.ParseDocOverrides("params", defaults, unit.Docs...)
+		c.check(unit.Lit, "function "+unit.Name, unit.Lit.Type, thresholds)
+	}
 
-		funcName := common.FuncName(funcDecl)
-		thresholds := common.ParseOverrides(funcDecl, "params", defaults)
+	return result, nil
+}
 
-		paramCount := countParams(funcDecl.Type)
-		zone := thresholds.Classify(paramCount)
+// checker counts and reports the signatures of one package.
+type checker struct {
+	pass      *analysis.Pass
+	defaults  common.Thresholds
+	exemption common.ParamExemption
+}
 
-		if zone == common.ZoneGreen {
-			return
+// typeDecl checks the named func types and interface methods declared by
+// genDecl, where a bad signature spreads to every implementation.
+func (c *checker) typeDecl(genDecl *ast.GenDecl) {
+	if genDecl.Tok != token.TYPE {
+		return
+	}
+	for _, spec := range genDecl.Specs {
+		typeSpec := spec.(*ast.TypeSpec)
+		switch t := typeSpec.Type.(type) {
+		case *ast.FuncType:
+			thresholds := common.ParseDocOverrides("params", c.defaults, typeSpec.Doc, genDecl.Doc)
+			c.check(typeSpec, "func type "+typeSpec.Name.Name, t, thresholds)
+		case *ast.InterfaceType:
+			c.interfaceMethods(typeSpec, t, genDecl.Doc)
 		}
+	}
+}
 
-		pass.Report(analysis.Diagnostic{
-			Pos:      funcDecl.Pos(),
+// interfaceMethods checks the methods of the interface declared by
+// typeSpec. Overrides on a method's doc comment take precedence over those
+// on the type.
+func (c *checker) interfaceMethods(typeSpec *ast.TypeSpec, it *ast.InterfaceType, declDoc *ast.CommentGroup) {
+	for _, field := range it.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			continue // embedded interface or constraint element
+		}
+		thresholds := common.ParseDocOverrides("params", c.defaults, field.Doc, typeSpec.Doc, declDoc)
+		for _, name := range field.Names {
+			c.check(name, "method "+typeSpec.Name.Name+"."+name.Name, ft, thresholds)
+		}
+	}
+}
+
+// check classifies the parameter count of a signature and reports it at
+// node outside the green zone. subject names the signature's owner, such as
+// "function Foo" or "method Store.Put". In weighted mode the count includes
+// the penalties of the parameters.
+func (c *checker) check(node ast.Node, subject string, funcType *ast.FuncType, thresholds common.Thresholds) common.Measure {
+	paramCount := countParams(c.pass.TypesInfo, c.exemption, funcType)
+	var penalties []penalty
+	if weighted {
+		penalties = weighParams(c.pass.TypesInfo, c.exemption, funcType, signatureOf(c.pass.TypesInfo, node))
+	}
+	value := paramCount + weight(penalties)
+	zone := thresholds.Classify(value)
+	if zone != common.ZoneGreen {
+		measured := fmt.Sprintf("%d parameters", paramCount)
+		if len(penalties) > 0 {
+			measured = fmt.Sprintf("a weighted parameter count of %d (%d parameters; penalized: %s)",
+				value, paramCount, describePenalties(penalties))
+		}
+		c.pass.Report(analysis.Diagnostic{
+			Pos:      node.Pos(),
 			Category: zone.Category(),
 			Message: fmt.Sprintf(
-				"function %s has %d parameters (warn: >=%d, fail: >=%d) [%s] "+
+				"%s has %s (warn: >=%d, fail: >=%d) [%s] "+
 					"(pair the two tightest params into a struct, repeat for remaining; extend a group only when coupled — never wrap all params in one struct)",
-				funcName, paramCount, thresholds.WarnAt, thresholds.FailAt,
+				subject, measured, thresholds.WarnAt, thresholds.FailAt,
 				zone.Category()),
 		})
-	})
-
-	return nil, nil
+	}
+	return common.Measure{Value: value, Thresholds: thresholds, Zone: zone}
 }
 
-// countParams counts the total number of parameters, handling grouped params.
+// CountParams counts the total number of parameters, handling grouped params.
 // func(a, b int, c string) has 3 params despite 2 field entries.
-// Idiomatic ctx context.Context parameters are excluded from the count.
-func countParams(funcType *ast.FuncType) int {
-	if funcType.Params == nil {
-		return 0
-	}
-	count := 0
-	for _, field := range funcType.Params.List {
-		if len(field.Names) == 0 {
-			// Unnamed parameter (e.g., in interface method signatures).
-			count++
-			continue
-		}
-		for _, name := range field.Names {
-			if common.IsExemptContextParam(name.Name, field.Type) {
-				continue
-			}
-			count++
-		}
-	}
-	return count
+// Parameters exempt under the -ctx-names and -exempt flags, by default
+// ctx context.Context, are excluded from the count; types are resolved with
+// info. Other analyzers use it to total parameters the same way params does.
+func CountParams(info *types.Info, funcType *ast.FuncType) int {
+	return countParams(info, exemption, funcType)
+}
+
+func countParams(info *types.Info, exemption common.ParamExemption, funcType *ast.FuncType) int {
+	return len(countedParams(info, exemption, funcType))
 }
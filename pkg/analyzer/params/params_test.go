@@ -3,6 +3,7 @@ package params_test
 import (
 	"testing"
 
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/params"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
@@ -11,3 +12,56 @@ func TestParams(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, params.Analyzer, "params")
 }
+
+func TestTypes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, params.Analyzer, "types")
+}
+
+func TestWeighted(t *testing.T) {
+	testutil.SetFlag(t, params.Analyzer, "weighted", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, params.Analyzer, "weighted")
+}
+
+func TestFuncLits(t *testing.T) {
+	testutil.SetFlag(t, params.Analyzer, "warn", "3")
+	testutil.SetFlag(t, params.Analyzer, "fail", "5")
+	testutil.SetFlag(t, params.Analyzer, "funclits", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, params.Analyzer, "funclits")
+}
+
+func TestExempt(t *testing.T) {
+	testutil.SetFlag(t, params.Analyzer, "ctx-names", "any")
+	testutil.SetFlag(t, params.Analyzer, "exempt", "testing,http,*exempt.Logger")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, params.Analyzer, "exempt")
+}
+
+func TestExemptCtxOnly(t *testing.T) {
+	testutil.SetFlag(t, params.Analyzer, "exempt", "http")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, params.Analyzer, "exemptctx")
+}
+
+func TestExemptFlagsInvalid(t *testing.T) {
+	testutil.SetFlag(t, params.Analyzer, "exempt", "http")
+
+	for name, value := range map[string]string{"ctx-names": "all", "exempt": "Logger"} {
+		if err := params.Analyzer.Flags.Set(name, value); err == nil {
+			t.Errorf("-%s=%s accepted, want error", name, value)
+		}
+	}
+	// A rejected value leaves the exemption unchanged.
+	if got := params.Analyzer.Flags.Lookup("exempt").Value.String(); got != "*net/http.Request,net/http.ResponseWriter" {
+		t.Errorf("-exempt = %q after an invalid value, want the http preset", got)
+	}
+	if got := params.Analyzer.Flags.Lookup("ctx-names").Value.String(); got != "ctx" {
+		t.Errorf("-ctx-names = %q after an invalid value, want ctx", got)
+	}
+}
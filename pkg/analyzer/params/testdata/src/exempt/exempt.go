@@ -0,0 +1,35 @@
+package exempt
+
+import (
+	stdctx "context"
+	"net/http"
+	"testing"
+)
+
+// Ctx is an alias of context.Context and is exempt like it.
+type Ctx = stdctx.Context
+
+// Logger is listed as an exempt type with -exempt=*exempt.Logger.
+type Logger struct{}
+
+// Each function below has 4 counted parameters with -ctx-names=any and
+// -exempt=testing,http,*exempt.Logger. Green zone.
+
+func Aliased(ctx stdctx.Context, a, b, c, d int) {}
+
+func TypeAlias(ctx Ctx, a, b, c, d int) {}
+
+func AnyName(c stdctx.Context, a, b, d, e int) {}
+
+func Blank(_ stdctx.Context, a, b, c, d int) {}
+
+func Handler(w http.ResponseWriter, r *http.Request, a, b, c, d int) {}
+
+func Helper(t *testing.T, a, b, c, d int) {}
+
+func Logged(log *Logger, a, b, c, d int) {}
+
+// Value takes Logger by value, which is not listed: 5 parameters. Yellow
+// zone.
+func Value(log Logger, a, b, c, d int) { // want `function Value has 5 parameters \(warn: >=5, fail: >=7\) \[warning\]`
+}
@@ -0,0 +1,15 @@
+package exemptctx
+
+import (
+	stdctx "context"
+	"net/http"
+)
+
+// With the default -ctx-names=ctx, a context parameter named otherwise
+// counts: 5 parameters. Yellow zone.
+func Named(c stdctx.Context, a, b, d, e int) { // want `function Named has 5 parameters \(warn: >=5, fail: >=7\) \[warning\]`
+}
+
+// Handler's (w, r) and ctx are exempt with -exempt=http: 4 parameters. Green
+// zone.
+func Handler(ctx stdctx.Context, w http.ResponseWriter, r *http.Request, a, b, c, d int) {}
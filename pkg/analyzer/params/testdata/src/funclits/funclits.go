@@ -0,0 +1,19 @@
+package funclits
+
+import "net/http"
+
+// handler is measured as handler.func1.
+var handler = func(w http.ResponseWriter, r *http.Request, user, role string) { // want `function handler.func1 has 4 parameters \(warn: >=3, fail: >=5\) \[warning\]`
+}
+
+// Pipeline's own signature is small; its stages are not.
+func Pipeline() {
+	stage := func(in, out chan int, name string, retries, workers int) { // want `function Pipeline.func1 has 5 parameters \(warn: >=3, fail: >=5\) \[error\]`
+	}
+	_ = stage
+
+	//complexity:params:warn=6,fail=8
+	merge := func(a, b, c, d, e chan int) {
+	}
+	_ = merge
+}
@@ -0,0 +1,48 @@
+package types
+
+import "context"
+
+// Store's Put takes 7 parameters; every implementation inherits them. Red
+// zone. Get is green.
+type Store interface {
+	Put(bucket, key, value, owner, group, mode, comment string) error // want `method Store.Put has 7 parameters \(warn: >=5, fail: >=7\) \[error\] \(pair the two tightest params into a struct, repeat for remaining; extend a group only when coupled — never wrap all params in one struct\)`
+	Get(ctx context.Context, bucket, key string) (string, error)
+
+	// List's overrides on its own doc comment win over the type's.
+	//
+	//complexity:params:warn=6,fail=8
+	List(bucket, prefix, cursor string, limit, offset int) ([]string, error)
+}
+
+// Unnamed parameters count too. Specs in a group are checked one by one.
+type (
+	Codec interface {
+		Encode(string, string, int, int, bool) error // want `method Codec.Encode has 5 parameters \(warn: >=5, fail: >=7\) \[warning\]`
+	}
+
+	// Handler has 5 parameters, ctx exempt. Yellow zone.
+	Handler func(ctx context.Context, method, path, query, body string, status int) // want `func type Handler has 5 parameters \(warn: >=5, fail: >=7\) \[warning\]`
+)
+
+// Visitor is overridden on its doc comment.
+//
+//complexity:params:warn=8,fail=10
+type Visitor func(a, b, c, d, e, f, g int)
+
+// Embedded interfaces are checked where they are declared.
+type ReadStore interface {
+	Store
+	Close() error
+}
+
+// Overrides on the type apply to every method.
+//
+//complexity:params:warn=2,fail=3
+type Small interface {
+	Pair(a, b int) // want `method Small.Pair has 2 parameters \(warn: >=2, fail: >=3\) \[warning\]`
+}
+
+func Local() {
+	type callback func(a, b, c, d, e, f, g string) // want `func type callback has 7 parameters \(warn: >=5, fail: >=7\) \[error\]`
+	var _ callback
+}
@@ -0,0 +1,48 @@
+package weighted
+
+import "context"
+
+type Point struct{ X, Y int }
+
+// Lookup's parameters all differ in type: weight 2. Green zone.
+func Lookup(ctx context.Context, id string, limit int) {}
+
+// Move's coordinates can be swapped unnoticed: 4 parameters, 3 transposable.
+// Weight 7. Red zone.
+func Move(fromX, fromY, toX, toY int) { // want `function Move has a weighted parameter count of 7 \(4 parameters; penalized: fromY \(transposable\), toX \(transposable\), toY \(transposable\)\) \(warn: >=5, fail: >=7\) \[error\]`
+}
+
+// MovePoints takes two points: 2 parameters, 1 transposable. Weight 3.
+// Green zone.
+func MovePoints(from, to Point) {}
+
+// Flags has the same count as Lookup, but both are flags. Weight 5. Yellow
+// zone.
+func Flags(verbose, force bool) { // want `function Flags has a weighted parameter count of 5 \(2 parameters; penalized: verbose \(flag\), force \(flag, transposable\)\) \(warn: >=5, fail: >=7\) \[warning\]`
+}
+
+// Callbacks takes a func and an any: weight 5. Yellow zone.
+func Callbacks(name string, visit func(string) error, data any) { // want `function Callbacks has a weighted parameter count of 5 \(3 parameters; penalized: visit \(func\), data \(any\)\) \(warn: >=5, fail: >=7\) \[warning\]`
+}
+
+// Generic type parameters are not penalized as any: weight 2. Green zone.
+func Generic[T any](v T, n int) {}
+
+// Options overrides apply to the weighted count.
+//
+//complexity:params:warn=12,fail=14
+func Options(a, b, c, d bool) {}
+
+// Unnamed parameters are labeled by position.
+type Renderer interface {
+	Render(string, bool, bool) error // want `method Renderer.Render has a weighted parameter count of 6 \(3 parameters; penalized: #2 \(flag\), #3 \(flag, transposable\)\) \(warn: >=5, fail: >=7\) \[warning\]`
+}
+
+// A variadic parameter is weighed by its element type: rest is a flag, and
+// transposable after b. Weight 8. Red zone.
+func Variadic(a, b bool, rest ...bool) { // want `function Variadic has a weighted parameter count of 8 \(3 parameters; penalized: a \(flag\), b \(flag, transposable\), rest \(flag, transposable\)\) \(warn: >=5, fail: >=7\) \[error\]`
+}
+
+// Emit's values take anything: weight 6. Yellow zone.
+func Emit(name string, value any, rest ...any) { // want `function Emit has a weighted parameter count of 6 \(3 parameters; penalized: value \(any\), rest \(any, transposable\)\) \(warn: >=5, fail: >=7\) \[warning\]`
+}
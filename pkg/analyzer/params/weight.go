@@ -0,0 +1,162 @@
+package params
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+)
+
+// penalty is the extra weight of one parameter in weighted mode: one per
+// reason.
+type penalty struct {
+	param   string // the parameter's name, or #N for an unnamed parameter
+	reasons []string
+}
+
+// param is a counted parameter of a signature.
+type param struct {
+	label string
+	index int // position in the signature's parameter list, from 0
+}
+
+// weighParams returns the penalties of funcType's counted parameters:
+//
+//   - flag: a bool, which makes callers pass true or false without a name
+//   - func: a func-typed parameter, a callback with a contract of its own
+//   - any: interface{} or any, whose type says nothing about the argument
+//   - transposable: the same type as the parameter before it, so swapped
+//     arguments still compile, as in Move(fromX, fromY, toX, toY int)
+//
+// Types come from sig, the signature funcType declares. A variadic rest
+// ...T is weighed as a T, the type of each argument it takes at a call site:
+// rest ...bool is a flag, and transposable after a bool.
+func weighParams(info *types.Info, exemption common.ParamExemption, funcType *ast.FuncType, sig *types.Signature) []penalty {
+	if sig == nil {
+		return nil
+	}
+	var penalties []penalty
+	var prev types.Type
+	for _, p := range countedParams(info, exemption, funcType) {
+		typ := argType(sig, p.index)
+		reasons := typePenalties(typ)
+		if prev != nil && types.Identical(prev, typ) {
+			reasons = append(reasons, "transposable")
+		}
+		if len(reasons) > 0 {
+			penalties = append(penalties, penalty{param: p.label, reasons: reasons})
+		}
+		prev = typ
+	}
+	return penalties
+}
+
+// argType returns the type of the arguments passed for sig's parameter i:
+// the element type of a variadic parameter, the parameter's type otherwise.
+func argType(sig *types.Signature, i int) types.Type {
+	typ := sig.Params().At(i).Type()
+	if sig.Variadic() && i == sig.Params().Len()-1 {
+		if slice, ok := typ.Underlying().(*types.Slice); ok {
+			return slice.Elem()
+		}
+	}
+	return typ
+}
+
+// signatureOf returns the signature of a function declaration, function
+// literal, named func type or interface method name, or nil.
+func signatureOf(info *types.Info, node ast.Node) *types.Signature {
+	var typ types.Type
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		typ = objectType(info, n.Name)
+	case *ast.TypeSpec:
+		typ = objectType(info, n.Name)
+	case *ast.Ident:
+		typ = objectType(info, n)
+	case *ast.FuncLit:
+		typ = info.TypeOf(n)
+	}
+	if typ == nil {
+		return nil
+	}
+	sig, _ := typ.Underlying().(*types.Signature)
+	return sig
+}
+
+func objectType(info *types.Info, name *ast.Ident) types.Type {
+	if obj := info.Defs[name]; obj != nil {
+		return obj.Type()
+	}
+	return nil
+}
+
+// countedParams returns funcType's parameters that are not exempt, in order.
+func countedParams(info *types.Info, exemption common.ParamExemption, funcType *ast.FuncType) []param {
+	if funcType.Params == nil {
+		return nil
+	}
+	var params []param
+	position := 0
+	for _, field := range funcType.Params.List {
+		if len(field.Names) == 0 {
+			position++
+			if !exemption.IsExempt(info, nil, field.Type) {
+				params = append(params, param{label: fmt.Sprintf("#%d", position), index: position - 1})
+			}
+			continue
+		}
+		for _, name := range field.Names {
+			position++
+			if !exemption.IsExempt(info, name, field.Type) {
+				params = append(params, param{label: name.Name, index: position - 1})
+			}
+		}
+	}
+	return params
+}
+
+// typePenalties returns the flag, func and any penalties of a parameter
+// type. Type parameters are not penalized, whatever their constraint.
+func typePenalties(t types.Type) []string {
+	if t == nil {
+		return nil
+	}
+	if _, ok := t.(*types.TypeParam); ok {
+		return nil
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		if u.Info()&types.IsBoolean != 0 {
+			return []string{"flag"}
+		}
+	case *types.Signature:
+		return []string{"func"}
+	case *types.Interface:
+		if u.Empty() {
+			return []string{"any"}
+		}
+	}
+	return nil
+}
+
+// weight is the total extra weight of penalties.
+func weight(penalties []penalty) int {
+	n := 0
+	for _, p := range penalties {
+		n += len(p.reasons)
+	}
+	return n
+}
+
+// describePenalties lists penalties for a diagnostic:
+// "verbose (flag), toY (transposable)".
+func describePenalties(penalties []penalty) string {
+	parts := make([]string, len(penalties))
+	for i, p := range penalties {
+		parts[i] = p.param + " (" + strings.Join(p.reasons, ", ") + ")"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,139 @@
+package risk
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/cyclo"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/fanout"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/nestdepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/params"
+	"golang.org/x/tools/go/analysis"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "risk",
+	Doc: "reports functions whose combined complexity is high\n\n" +
+		"Combines the nestdepth, cyclo, params and fanout measures of each " +
+		"function into one score: each value is taken as a percentage of its " +
+		"warn threshold (after overrides), capped at 100, and the percentages " +
+		"are averaged with configurable weights. A function close to every " +
+		"threshold scores higher than one far over a single threshold, which " +
+		"that metric's own analyzer already reports.",
+	Run: run,
+	Requires: []*analysis.Analyzer{
+		nestdepth.Analyzer, cyclo.Analyzer, params.Analyzer, fanout.Analyzer,
+	},
+}
+
+var (
+	warnAt          int
+	failAt          int
+	nestdepthWeight int
+	cycloWeight     int
+	paramsWeight    int
+	fanoutWeight    int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 70,
+		"risk score at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 90,
+		"risk score at or above this triggers a failure (red zone)")
+	Analyzer.Flags.IntVar(&nestdepthWeight, "nestdepth-weight", 1,
+		"weight of nesting depth in the risk score")
+	Analyzer.Flags.IntVar(&cycloWeight, "cyclo-weight", 1,
+		"weight of cyclomatic complexity in the risk score")
+	Analyzer.Flags.IntVar(&paramsWeight, "params-weight", 1,
+		"weight of parameter count in the risk score")
+	Analyzer.Flags.IntVar(&fanoutWeight, "fanout-weight", 1,
+		"weight of fan out in the risk score")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+// component is one metric's contribution to the score.
+type component struct {
+	name    string
+	weight  int
+	results map[*ast.FuncDecl]common.Measure
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := defaults.Validate("risk"); err != nil {
+		return nil, err
+	}
+	components := []component{
+		{"nestdepth", nestdepthWeight, pass.ResultOf[nestdepth.Analyzer].(nestdepth.Result)},
+		{"cyclo", cycloWeight, pass.ResultOf[cyclo.Analyzer].(cyclo.Result)},
+		{"params", paramsWeight, pass.ResultOf[params.Analyzer].(params.Result)},
+		{"fanout", fanoutWeight, pass.ResultOf[fanout.Analyzer].(fanout.Result)},
+	}
+	totalWeight := 0
+	for _, c := range components {
+		if c.weight < 0 {
+			return nil, fmt.Errorf("risk: %s weight must be non-negative, got %d", c.name, c.weight)
+		}
+		totalWeight += c.weight
+	}
+	if totalWeight == 0 {
+		return nil, fmt.Errorf("risk: at least one weight must be positive")
+	}
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			// cyclo measures every function with a body outside excluded files.
+			if _, measured := components[1].results[funcDecl]; !ok || !measured {
+				continue
+			}
+			score, percents := combine(components, funcDecl, totalWeight)
+			report(pass, funcDecl, score, percents, common.ParseOverrides(funcDecl, "risk", defaults))
+		}
+	}
+
+	return nil, nil
+}
+
+// combine returns the weighted risk score of funcDecl and the percentage of
+// each component's warn threshold it reaches.
+func combine(components []component, funcDecl *ast.FuncDecl, totalWeight int) (int, []int) {
+	percents := make([]int, len(components))
+	weighted := 0
+	for i, c := range components {
+		percents[i] = percentOfWarn(c.results[funcDecl])
+		weighted += c.weight * percents[i]
+	}
+	return weighted / totalWeight, percents
+}
+
+func report(pass *analysis.Pass, funcDecl *ast.FuncDecl, score int, percents []int, thresholds common.Thresholds) {
+	zone := thresholds.Classify(score)
+	if zone == common.ZoneGreen {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      funcDecl.Pos(),
+		Category: zone.Category(),
+		Message: fmt.Sprintf(
+			"function %s has a risk score of %d (nestdepth: %d%%, cyclo: %d%%, params: %d%%, fanout: %d%% of their warn thresholds) (warn: >=%d, fail: >=%d) [%s] "+
+				"(reduce by splitting the function so that each part stays well below every threshold)",
+			common.FuncName(funcDecl), score, percents[0], percents[1], percents[2], percents[3],
+			thresholds.WarnAt, thresholds.FailAt, zone.Category()),
+	})
+}
+
+// percentOfWarn returns m's value as a percentage of its warn threshold,
+// capped at 100. A warn threshold of 0 puts any value at 100.
+func percentOfWarn(m common.Measure) int {
+	if m.Thresholds.WarnAt == 0 {
+		if m.Value > 0 {
+			return 100
+		}
+		return 0
+	}
+	return min(100, m.Value*100/m.Thresholds.WarnAt)
+}
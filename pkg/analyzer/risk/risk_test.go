@@ -0,0 +1,35 @@
+package risk_test
+
+import (
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/cyclo"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/fanout"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/nestdepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/params"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/risk"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRisk(t *testing.T) {
+	// Small warn thresholds keep the testdata short.
+	testutil.SetFlag(t, nestdepth.Analyzer, "warn", "3")
+	testutil.SetFlag(t, cyclo.Analyzer, "warn", "4")
+	testutil.SetFlag(t, params.Analyzer, "warn", "4")
+	testutil.SetFlag(t, fanout.Analyzer, "warn", "4")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, risk.Analyzer, "risk")
+}
+
+func TestRiskWeights(t *testing.T) {
+	testutil.SetFlag(t, nestdepth.Analyzer, "warn", "3")
+	testutil.SetFlag(t, cyclo.Analyzer, "warn", "4")
+	testutil.SetFlag(t, params.Analyzer, "warn", "4")
+	testutil.SetFlag(t, fanout.Analyzer, "warn", "4")
+	testutil.SetFlag(t, risk.Analyzer, "cyclo-weight", "6")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, risk.Analyzer, "weighted")
+}
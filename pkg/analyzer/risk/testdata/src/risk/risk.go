@@ -0,0 +1,65 @@
+package risk
+
+func helper() {}
+func a()      {}
+func b()      {}
+func c()      {}
+
+// spiky is far over the cyclo threshold but low on everything else, so its
+// risk stays green: cyclo alone reports it.
+func spiky(x int) {
+	if x == 1 {
+	}
+	if x == 2 {
+	}
+	if x == 3 {
+	}
+	if x == 4 {
+	}
+	if x == 5 {
+	}
+	if x == 6 {
+	}
+}
+
+// broad is just below every threshold (warn: nestdepth 3, cyclo 4, params 4,
+// fanout 4) and is reported.
+func broad(p1, p2, p3 int) { // want `function broad has a risk score of 72 \(nestdepth: 66%, cyclo: 75%, params: 75%, fanout: 75% of their warn thresholds\) \(warn: >=70, fail: >=90\) \[warning\] \(reduce by splitting the function so that each part stays well below every threshold\)`
+	if p1 > 0 {
+		if p2 > 0 {
+			helper()
+		}
+	}
+	a()
+	b()
+	_ = p3
+}
+
+// full reaches every warn threshold.
+func full(p1, p2, p3, p4 int) { // want `function full has a risk score of 100 \(nestdepth: 100%, cyclo: 100%, params: 100%, fanout: 100% of their warn thresholds\) \(warn: >=70, fail: >=90\) \[error\]`
+	if p1 > 0 {
+		if p2 > 0 {
+			for range p3 {
+				helper()
+			}
+		}
+	}
+	a()
+	b()
+	c()
+	_ = p4
+}
+
+//complexity:risk:warn=100,fail=100 Reviewed.
+//complexity:cyclo:warn=8,fail=10
+func overridden(p1, p2, p3 int) {
+	if p1 > 0 {
+		if p2 > 0 {
+			helper()
+		}
+	}
+	if p3 > 0 {
+		a()
+		b()
+	}
+}
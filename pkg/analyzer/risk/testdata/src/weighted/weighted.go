@@ -0,0 +1,18 @@
+package weighted
+
+// spiky is far over the cyclo threshold only; with cyclo weighted six times
+// as heavily as the other metrics it is reported.
+func spiky(x int) { // want `function spiky has a risk score of 73 \(nestdepth: 33%, cyclo: 100%, params: 25%, fanout: 0% of their warn thresholds\)`
+	if x == 1 {
+	}
+	if x == 2 {
+	}
+	if x == 3 {
+	}
+	if x == 4 {
+	}
+	if x == 5 {
+	}
+	if x == 6 {
+	}
+}
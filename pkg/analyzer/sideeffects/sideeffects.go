@@ -0,0 +1,273 @@
+package sideeffects
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"strings"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "sideeffects",
+	Doc: "reports functions with many side-effecting calls and global writes\n\n" +
+		"Counts every call to a function or method declared in an effectful " +
+		"package (file, process, network and database I/O by default; see " +
+		"-packages and -extra-packages) and every write to a package-level " +
+		"variable. Pure helpers of those packages, such as os.Getenv or " +
+		"http.StatusText, do not count. Unlike fanout, " +
+		"standard library calls count and repeated calls are not deduplicated: " +
+		"each is a separate point where the function touches the outside world.",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// defaultPackages are the standard library packages whose calls perform I/O
+// or touch process state.
+const defaultPackages = "os,os/exec,io,io/fs,io/ioutil,net,net/http,database/sql,syscall"
+
+// pureFuncs are the functions and methods of the default packages that do
+// no I/O: error classification, environment lookups, constructors that
+// only build a value, and accessors of values already read. Keys are
+// types.Func.FullName.
+var pureFuncs = map[string]bool{
+	"os.Getenv":                       true,
+	"os.LookupEnv":                    true,
+	"os.ExpandEnv":                    true,
+	"os.Expand":                       true,
+	"os.IsExist":                      true,
+	"os.IsNotExist":                   true,
+	"os.IsPermission":                 true,
+	"os.IsTimeout":                    true,
+	"os.IsPathSeparator":              true,
+	"os.NewSyscallError":              true,
+	"(*os.File).Name":                 true,
+	"(*io/fs.PathError).Error":        true,
+	"(*io/fs.PathError).Unwrap":       true,
+	"(*io/fs.PathError).Timeout":      true,
+	"(io/fs.FileMode).IsDir":          true,
+	"(io/fs.FileMode).IsRegular":      true,
+	"(io/fs.FileMode).Perm":           true,
+	"(io/fs.FileMode).String":         true,
+	"(io/fs.FileMode).Type":           true,
+	"(io/fs.FileInfo).Name":           true,
+	"(io/fs.FileInfo).Size":           true,
+	"(io/fs.FileInfo).Mode":           true,
+	"(io/fs.FileInfo).ModTime":        true,
+	"(io/fs.FileInfo).IsDir":          true,
+	"(io/fs.FileInfo).Sys":            true,
+	"(io/fs.DirEntry).Name":           true,
+	"(io/fs.DirEntry).IsDir":          true,
+	"(io/fs.DirEntry).Type":           true,
+	"io/fs.ValidPath":                 true,
+	"os/exec.Command":                 true,
+	"os/exec.CommandContext":          true,
+	"io.LimitReader":                  true,
+	"io.MultiReader":                  true,
+	"io.MultiWriter":                  true,
+	"io.NewSectionReader":             true,
+	"io.NopCloser":                    true,
+	"io.TeeReader":                    true,
+	"io/ioutil.NopCloser":             true,
+	"net.CIDRMask":                    true,
+	"net.IPv4":                        true,
+	"net.IPv4Mask":                    true,
+	"net.JoinHostPort":                true,
+	"net.ParseCIDR":                   true,
+	"net.ParseIP":                     true,
+	"net.ParseMAC":                    true,
+	"net.SplitHostPort":               true,
+	"(net.IP).Equal":                  true,
+	"(net.IP).String":                 true,
+	"(net.IP).To4":                    true,
+	"(net.IP).To16":                   true,
+	"net/http.CanonicalHeaderKey":     true,
+	"net/http.DetectContentType":      true,
+	"net/http.NewRequest":             true,
+	"net/http.NewRequestWithContext":  true,
+	"net/http.ParseHTTPVersion":       true,
+	"net/http.ParseTime":              true,
+	"net/http.StatusText":             true,
+	"(net/http.Header).Add":           true,
+	"(net/http.Header).Clone":         true,
+	"(net/http.Header).Del":           true,
+	"(net/http.Header).Get":           true,
+	"(net/http.Header).Set":           true,
+	"(net/http.Header).Values":        true,
+	"(*net/http.Request).Context":     true,
+	"(*net/http.Request).Clone":       true,
+	"(*net/http.Request).Cookie":      true,
+	"(*net/http.Request).UserAgent":   true,
+	"(*net/http.Request).WithContext": true,
+	"database/sql.Named":              true,
+}
+
+var (
+	warnAt        int
+	failAt        int
+	packages      string
+	extraPackages string
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 5,
+		"side effect count at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 10,
+		"side effect count at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&packages, "packages", defaultPackages,
+		"comma-separated import paths or glob patterns of effectful packages (replaces the default list)")
+	Analyzer.Flags.StringVar(&extraPackages, "extra-packages", "",
+		"comma-separated import paths or glob patterns of effectful packages added to -packages (e.g. example.com/app/store)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+// effects tallies a function's side effects by kind.
+type effects struct {
+	calls, globals int
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := defaults.Validate("sideeffects"); err != nil {
+		return nil, err
+	}
+	patterns := effectfulPatterns()
+
+	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		funcDecl := n.(*ast.FuncDecl)
+		if funcDecl.Body == nil {
+			return
+		}
+		if common.IsExcluded(pass.Fset.Position(funcDecl.Pos()).Filename) {
+			return
+		}
+
+		thresholds := common.ParseOverrides(funcDecl, "sideeffects", defaults)
+
+		e := countEffects(pass, funcDecl.Body, patterns)
+		if thresholds.Classify(e.calls+e.globals) != common.ZoneGreen {
+			report(pass, funcDecl, e, thresholds)
+		}
+	})
+
+	return nil, nil
+}
+
+// report reports the side effects e of funcDecl in its zone.
+func report(pass *analysis.Pass, funcDecl *ast.FuncDecl, e effects, thresholds common.Thresholds) {
+	zone := thresholds.Classify(e.calls + e.globals)
+	pass.Report(analysis.Diagnostic{
+		Pos:      funcDecl.Pos(),
+		Category: zone.Category(),
+		Message: fmt.Sprintf(
+			"function %s has %d side effects (effectful calls: %d, package variable writes: %d) (warn: >=%d, fail: >=%d) [%s] "+
+				"(reduce by moving I/O to the edges: pass in readers, writers and interfaces, and return values instead of updating package state)",
+			common.FuncName(funcDecl), e.calls+e.globals, e.calls, e.globals,
+			thresholds.WarnAt, thresholds.FailAt, zone.Category()),
+	})
+}
+
+// countEffects counts effectful calls and package-level variable writes in
+// body, closures included.
+func countEffects(pass *analysis.Pass, body *ast.BlockStmt, patterns []string) effects {
+	var e effects
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.CallExpr:
+			if isEffectfulCall(pass, s, patterns) {
+				e.calls++
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range s.Lhs {
+				if s.Tok != token.DEFINE && isPackageVar(pass, lhs) {
+					e.globals++
+				}
+			}
+		case *ast.IncDecStmt:
+			if isPackageVar(pass, s.X) {
+				e.globals++
+			}
+		}
+		return true
+	})
+	return e
+}
+
+// isEffectfulCall reports whether call invokes a function or method declared
+// in a package matching patterns, other than a pure helper. Methods are
+// attributed to the package of their declaration, so (*os.File).Write and
+// io.Writer.Write both count.
+func isEffectfulCall(pass *analysis.Pass, call *ast.CallExpr, patterns []string) bool {
+	var obj types.Object
+	switch fn := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		obj = pass.TypesInfo.ObjectOf(fn)
+	case *ast.SelectorExpr:
+		obj = pass.TypesInfo.ObjectOf(fn.Sel)
+	}
+	f, ok := obj.(*types.Func)
+	if !ok || f.Pkg() == nil || pureFuncs[f.Origin().FullName()] {
+		return false
+	}
+	for _, p := range patterns {
+		if matched, _ := path.Match(p, f.Pkg().Path()); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// isPackageVar reports whether an assignment to lhs writes a package-level
+// variable, directly or through a field, element or pointee of one.
+func isPackageVar(pass *analysis.Pass, lhs ast.Expr) bool {
+	for {
+		switch e := lhs.(type) {
+		case *ast.ParenExpr:
+			lhs = e.X
+		case *ast.StarExpr:
+			lhs = e.X
+		case *ast.IndexExpr:
+			lhs = e.X
+		case *ast.SelectorExpr:
+			if _, ok := pass.TypesInfo.Selections[e]; !ok {
+				return isPackageLevel(pass.TypesInfo.ObjectOf(e.Sel)) // pkg.Var
+			}
+			lhs = e.X
+		case *ast.Ident:
+			return isPackageLevel(pass.TypesInfo.ObjectOf(e))
+		default:
+			return false
+		}
+	}
+}
+
+func isPackageLevel(obj types.Object) bool {
+	v, ok := obj.(*types.Var)
+	return ok && !v.IsField() && v.Pkg() != nil && v.Parent() == v.Pkg().Scope()
+}
+
+// effectfulPatterns returns the package patterns of -packages and
+// -extra-packages.
+func effectfulPatterns() []string {
+	return append(splitPatterns(packages), splitPatterns(extraPackages)...)
+}
+
+func splitPatterns(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,27 @@
+package sideeffects_test
+
+import (
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/sideeffects"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestSideEffects(t *testing.T) {
+	testutil.SetFlag(t, sideeffects.Analyzer, "warn", "4")
+	testutil.SetFlag(t, sideeffects.Analyzer, "fail", "8")
+	testutil.SetFlag(t, sideeffects.Analyzer, "extra-packages", "ext.pkg/store")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, sideeffects.Analyzer, "sideeffects")
+}
+
+func TestPackagesReplaced(t *testing.T) {
+	testutil.SetFlag(t, sideeffects.Analyzer, "warn", "4")
+	testutil.SetFlag(t, sideeffects.Analyzer, "fail", "8")
+	testutil.SetFlag(t, sideeffects.Analyzer, "packages", "ext.pkg/store")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, sideeffects.Analyzer, "replaced")
+}
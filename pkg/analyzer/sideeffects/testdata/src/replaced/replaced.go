@@ -0,0 +1,22 @@
+package replaced
+
+import (
+	"os"
+
+	"ext.pkg/store"
+)
+
+// files only touches packages outside the replaced list.
+func files() {
+	os.Remove("a")
+	os.Remove("b")
+	os.Remove("c")
+	os.Remove("d")
+}
+
+func saves() { // want `function saves has 4 side effects \(effectful calls: 4, package variable writes: 0\) \(warn: >=4, fail: >=8\) \[warning\]`
+	store.Save("a")
+	store.Save("b")
+	store.Save("c")
+	store.Save("d")
+}
@@ -0,0 +1,72 @@
+package sideeffects
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"ext.pkg/store"
+)
+
+var (
+	cache   = map[string]string{}
+	counter int
+	config  struct{ Path string }
+)
+
+// pure only calls side-effect-free code.
+func pure(s string) string {
+	local := 0
+	local++
+	return strings.ToUpper(s) + store.Pure(s)
+}
+
+func load(name string, w io.Writer) error { // want `function load has 6 side effects \(effectful calls: 4, package variable writes: 2\) \(warn: >=4, fail: >=8\) \[warning\] \(reduce by moving I/O to the edges: pass in readers, writers and interfaces, and return values instead of updating package state\)`
+	f, err := os.Open(name) // os
+	if err != nil {
+		return err
+	}
+	defer f.Close()            // method declared in os
+	data, err := io.ReadAll(f) // io
+	if err != nil {
+		return err
+	}
+	w.Write(data)              // io.Writer method
+	cache[name] = string(data) // package variable element
+	counter++                  // package variable
+	return nil
+}
+
+func persist(key string) { // want `function persist has 8 side effects \(effectful calls: 2, package variable writes: 6\) \(warn: >=4, fail: >=8\) \[error\]`
+	config.Path = key // field of a package variable
+	store.Hits++      // another package's variable
+	store.Save(key)   // configured effectful package
+	func() {
+		store.Save(key) // closures count toward the enclosing function
+		counter = 1
+	}()
+	counter, cache = 0, nil
+	var local int
+	local = 2
+	_ = local
+	counter += 1
+}
+
+// helpers only calls pure helpers of effectful packages, which do no I/O.
+func helpers(err error) string {
+	if os.IsNotExist(err) || errors.Is(err, io.EOF) {
+		return os.Getenv("HOME")
+	}
+	req, _ := http.NewRequest("GET", "/", nil)
+	return http.StatusText(404) + req.Header.Get("Accept")
+}
+
+//complexity:sideeffects:warn=10,fail=20 Process entry point wiring.
+func overridden() {
+	os.Remove("A")
+	os.Remove("B")
+	os.Remove("C")
+	os.Remove("D")
+}
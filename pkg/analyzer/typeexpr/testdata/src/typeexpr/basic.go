@@ -0,0 +1,65 @@
+package typeexpr
+
+import "context"
+
+type T struct{}
+
+// Simple has flat parameter types. Green zone.
+func Simple(a int, b string, c *T) error {
+	return nil
+}
+
+// ShallowMap has depth 2, size 3. Green zone.
+func ShallowMap(m map[string]int) {}
+
+// DepthFive has depth 5: [](1) -> [](2) -> [](3) -> [](4) -> int(5). Yellow zone.
+func DepthFive(x [][][][]int) {} // want `type expression \[\]\[\]\[\]\[\]int has a depth of 5 \(warn: >=5, fail: >=7\) \[warning\] \(reduce by naming inner parts of the type with type declarations\)`
+
+// Composed is the motivating example.
+// map(1) -> [](2) -> map(3) -> chan(4) -> func(5) -> *(6) -> T(7): depth 7, red zone.
+// map, string, [], map, int, chan, func, context.Context, *, T, error: size 11, yellow zone.
+var Composed map[string][]map[int]chan func(context.Context) (*T, error) // want `type expression map\[string\]\[\]map\[int\]chan func\(context.Context\) \(\*T, error\) has a depth of 7 \(warn: >=5, fail: >=7\) \[error\]` `type expression map\[string\]\[\]map\[int\]chan func\(context.Context\) \(\*T, error\) has a size of 11 \(warn: >=10, fail: >=15\) \[warning\]`
+
+// WideFunc has depth 3 but size 10: func, 4 x (*, T), error. Yellow zone.
+type WideFunc func(a, b *T, c *T, d *T, e *T) error // want `type expression func\(a, b \*T, c \*T, d \*T, e \*T\) error has a size of 10 \(warn: >=10, fail: >=15\) \[warning\] \(reduce by naming inner parts of the type with type declarations or grouping related values into a struct\)`
+
+// Record's fields are measured individually; the struct itself is one node.
+type Record struct {
+	ID    int
+	Index map[string][][]*T // want `type expression map\[string\]\[\]\[\]\*T has a depth of 5`
+
+	//complexity:typeexpr:warn=6,fail=8 Lookup table shape is fixed by the wire format.
+	Lookup map[string][][]*T
+}
+
+// Store's method signatures are measured like function signatures.
+type Store interface {
+	Get(key string) (chan [][][]int, error) // want `type expression chan \[\]\[\]\[\]int has a depth of 5`
+}
+
+// Result types are measured.
+func Results() (out *[][][]int) { // want `type expression \*\[\]\[\]\[\]int has a depth of 5`
+	return nil
+}
+
+// Locals tests variable declarations and closures inside a function body.
+func Locals() {
+	var x [][][][]int // want `type expression \[\]\[\]\[\]\[\]int has a depth of 5`
+	_ = x
+	f := func(y **[][]int) {} // want `type expression \*\*\[\]\[\]int has a depth of 5`
+	_ = f
+}
+
+// Overridden raises the depth threshold for its whole signature and body.
+//
+//complexity:typeexpr:warn=6,fail=8
+func Overridden(x [][][][]int) {
+	var y [][][][]int
+	_ = y
+}
+
+// Generic instantiations add a level above their deepest argument.
+type List[E any] struct{ items []E }
+
+// Nested has depth 5: List(1) -> List(2) -> List(3) -> *(4) -> T(5).
+func Nested(l List[List[List[*T]]]) {} // want `type expression List\[List\[List\[\*T\]\]\] has a depth of 5`
@@ -0,0 +1,266 @@
+package typeexpr
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "typeexpr",
+	Doc: "reports type expressions that are too deeply composed or too large\n\n" +
+		"Measures the type of every parameter, result, struct field, interface " +
+		"method and variable declaration. Depth is the height of the type tree " +
+		"(pointer, slice, array, map, chan, func and generic instantiation each " +
+		"add a level); size is the number of type names and constructors in it. " +
+		"Struct and interface literals count as a single node and their fields " +
+		"are measured on their own.",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+var (
+	warnAt     int
+	failAt     int
+	sizeWarnAt int
+	sizeFailAt int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 5,
+		"type expression depth at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 7,
+		"type expression depth at or above this triggers a failure (red zone)")
+	Analyzer.Flags.IntVar(&sizeWarnAt, "size-warn", 10,
+		"type expression size at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&sizeFailAt, "size-fail", 15,
+		"type expression size at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+// root is a type expression measured on its own, with the doc comments
+// searched (in order) for override directives.
+type root struct {
+	expr ast.Expr
+	docs []*ast.CommentGroup
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	depthDefaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := depthDefaults.Validate("typeexpr"); err != nil {
+		return nil, err
+	}
+	sizeDefaults := common.Thresholds{WarnAt: sizeWarnAt, FailAt: sizeFailAt}
+	if err := sizeDefaults.Validate("typeexpr-size"); err != nil {
+		return nil, err
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+		(*ast.GenDecl)(nil),
+	}
+
+	insp.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		if common.IsExcluded(pass.Fset.Position(n.Pos()).Filename) {
+			return false
+		}
+
+		var roots []root
+		switch decl := n.(type) {
+		case *ast.FuncDecl:
+			roots = funcTypeRoots(decl.Type, decl.Doc)
+		case *ast.FuncLit:
+			roots = funcTypeRoots(decl.Type, enclosingFuncDoc(stack))
+		case *ast.GenDecl:
+			roots = genDeclRoots(decl, enclosingFuncDoc(stack))
+		}
+
+		checkRoots(pass, roots, depthDefaults, sizeDefaults)
+		return true
+	})
+
+	return nil, nil
+}
+
+// checkRoots measures each root and reports those outside the green zone.
+// Fields of struct and interface literals are queued as further roots.
+func checkRoots(pass *analysis.Pass, roots []root, depthDefaults, sizeDefaults common.Thresholds) {
+	for len(roots) > 0 {
+		r := roots[0]
+		roots = roots[1:]
+
+		m := measure(r.expr, r, &roots)
+
+		depthThresholds := common.ParseDocOverrides("typeexpr", depthDefaults, r.docs...)
+		report(pass, r.expr, "depth", m.depth, depthThresholds,
+			"reduce by naming inner parts of the type with type declarations")
+
+		sizeThresholds := common.ParseDocOverrides("typeexpr-size", sizeDefaults, r.docs...)
+		report(pass, r.expr, "size", m.size, sizeThresholds,
+			"reduce by naming inner parts of the type with type declarations or grouping related values into a struct")
+	}
+}
+
+func report(pass *analysis.Pass, expr ast.Expr, metric string, value int, thresholds common.Thresholds, advice string) {
+	zone := thresholds.Classify(value)
+	if zone == common.ZoneGreen {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      expr.Pos(),
+		End:      expr.End(),
+		Category: zone.Category(),
+		Message: fmt.Sprintf(
+			"type expression %s has a %s of %d (warn: >=%d, fail: >=%d) [%s] (%s)",
+			types.ExprString(expr), metric, value, thresholds.WarnAt, thresholds.FailAt,
+			zone.Category(), advice),
+	})
+}
+
+// enclosingFuncDoc returns the doc comment of the innermost FuncDecl on the
+// stack, so that closures and local declarations share its overrides.
+func enclosingFuncDoc(stack []ast.Node) *ast.CommentGroup {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if fd, ok := stack[i].(*ast.FuncDecl); ok {
+			return fd.Doc
+		}
+	}
+	return nil
+}
+
+// funcTypeRoots returns the parameter and result types of a signature.
+// Type parameters and receivers are not measured.
+func funcTypeRoots(ft *ast.FuncType, docs ...*ast.CommentGroup) []root {
+	var roots []root
+	for _, fl := range []*ast.FieldList{ft.Params, ft.Results} {
+		roots = append(roots, fieldRoots(fl, docs...)...)
+	}
+	return roots
+}
+
+// fieldRoots returns one root per field. Grouped names (a, b T) share a
+// single type expression and are measured once.
+func fieldRoots(fl *ast.FieldList, docs ...*ast.CommentGroup) []root {
+	if fl == nil {
+		return nil
+	}
+	roots := make([]root, 0, len(fl.List))
+	for _, field := range fl.List {
+		roots = append(roots, root{
+			expr: field.Type,
+			docs: append([]*ast.CommentGroup{field.Doc}, docs...),
+		})
+	}
+	return roots
+}
+
+func genDeclRoots(decl *ast.GenDecl, funcDoc *ast.CommentGroup) []root {
+	var roots []root
+	for _, spec := range decl.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			roots = append(roots, root{expr: s.Type, docs: []*ast.CommentGroup{s.Doc, decl.Doc, funcDoc}})
+		case *ast.ValueSpec:
+			if s.Type != nil {
+				roots = append(roots, root{expr: s.Type, docs: []*ast.CommentGroup{s.Doc, decl.Doc, funcDoc}})
+			}
+		}
+	}
+	return roots
+}
+
+type metrics struct {
+	depth int
+	size  int
+}
+
+// measure computes the depth and size of a type expression. Struct and
+// interface literals count as one node; their fields are appended to queue
+// as separate roots inheriting the parent's override docs.
+func measure(expr ast.Expr, parent root, queue *[]root) metrics {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return measure(e.X, parent, queue)
+	case *ast.UnaryExpr:
+		// ~T in a constraint
+		return measure(e.X, parent, queue)
+	case *ast.BinaryExpr:
+		// A | B in a constraint: the widest term, every term's size.
+		x, y := measure(e.X, parent, queue), measure(e.Y, parent, queue)
+		return metrics{depth: max(x.depth, y.depth), size: x.size + y.size}
+	case *ast.StarExpr:
+		return wrap(measure(e.X, parent, queue))
+	case *ast.ArrayType:
+		return wrap(measure(e.Elt, parent, queue))
+	case *ast.Ellipsis:
+		return wrap(measure(e.Elt, parent, queue))
+	case *ast.ChanType:
+		return wrap(measure(e.Value, parent, queue))
+	case *ast.MapType:
+		k, v := measure(e.Key, parent, queue), measure(e.Value, parent, queue)
+		return metrics{depth: 1 + max(k.depth, v.depth), size: 1 + k.size + v.size}
+	case *ast.FuncType:
+		m := metrics{depth: 1, size: 1}
+		for _, fl := range []*ast.FieldList{e.Params, e.Results} {
+			if fl == nil {
+				continue
+			}
+			for _, field := range fl.List {
+				f := measure(field.Type, parent, queue)
+				m.depth = max(m.depth, 1+f.depth)
+				m.size += f.size
+			}
+		}
+		return m
+	case *ast.IndexExpr:
+		return instantiation(e.X, []ast.Expr{e.Index}, parent, queue)
+	case *ast.IndexListExpr:
+		return instantiation(e.X, e.Indices, parent, queue)
+	case *ast.StructType:
+		*queue = append(*queue, fieldRoots(e.Fields, parent.docs...)...)
+		return metrics{depth: 1, size: 1}
+	case *ast.InterfaceType:
+		for _, field := range e.Methods.List {
+			if ft, ok := field.Type.(*ast.FuncType); ok {
+				docs := append([]*ast.CommentGroup{field.Doc}, parent.docs...)
+				*queue = append(*queue, funcTypeRoots(ft, docs...)...)
+				continue
+			}
+			// Embedded interface or constraint element.
+			*queue = append(*queue, root{expr: field.Type, docs: parent.docs})
+		}
+		return metrics{depth: 1, size: 1}
+	default:
+		// Identifiers, qualified names and anything else are leaves.
+		return metrics{depth: 1, size: 1}
+	}
+}
+
+// wrap adds one constructor level (pointer, slice, chan, ...) around m.
+func wrap(m metrics) metrics {
+	return metrics{depth: m.depth + 1, size: m.size + 1}
+}
+
+// instantiation measures a generic type instantiation: one level above its
+// deepest type argument.
+func instantiation(x ast.Expr, args []ast.Expr, parent root, queue *[]root) metrics {
+	m := measure(x, parent, queue)
+	for _, arg := range args {
+		a := measure(arg, parent, queue)
+		m.depth = max(m.depth, 1+a.depth)
+		m.size += a.size
+	}
+	return m
+}
@@ -0,0 +1,13 @@
+package typeexpr_test
+
+import (
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/typeexpr"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestTypeExpr(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, typeexpr.Analyzer, "typeexpr")
+}
@@ -0,0 +1,46 @@
+package varspan
+
+// tight uses every variable right after declaring it.
+func tight(xs []int) int {
+	sum := 0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum
+}
+
+func distant(xs []int) int { // want `function distant has a largest variable span of 8 lines \(limit, declared on line 13\) \(warn: >=7, fail: >=10\) \[warning\] \(reduce by declaring variables just before their first use, or extracting the code between declaration and use into functions\)` `function distant has a largest variable span of 8 statements \(limit, declared on line 13\) \(warn: >=5, fail: >=10\) \[warning\]`
+	limit := 10
+	a := 1
+	b := 2
+	c := a + b
+	d := c * 2
+	e := d - 1
+	f := e + c
+	_ = f
+	return limit
+}
+
+func average(xs []int) int { // want `function average has an average variable span of 5.5 lines \(warn: >=5, fail: >=8\) \[warning\]`
+	a := 1
+	b := 2
+
+	_ = xs
+
+	// The return is far from a and b.
+	return a + b
+}
+
+//complexity:varspan:warn=20,fail=30
+//complexity:varspan-stmts:warn=20,fail=30 Setup is shared by the whole table.
+func overridden() int {
+	limit := 10
+	a := 1
+	b := a + 1
+	c := b + 1
+	d := c + 1
+	e := d + 1
+	f := e + 1
+	_ = f
+	return limit
+}
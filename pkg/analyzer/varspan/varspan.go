@@ -0,0 +1,206 @@
+package varspan
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/common"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "varspan",
+	Doc: "reports functions whose local variables live far from their uses\n\n" +
+		"The span of a local variable is the distance from its declaration to " +
+		"its last use, measured in lines and in statements. Reports functions " +
+		"whose largest line span, average line span or largest statement span " +
+		"is too high. Parameters and named results are not measured.",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+var (
+	warnAt      int
+	failAt      int
+	avgWarnAt   int
+	avgFailAt   int
+	stmtsWarnAt int
+	stmtsFailAt int
+)
+
+func init() {
+	Analyzer.Flags.IntVar(&warnAt, "warn", 40,
+		"largest variable span in lines at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&failAt, "fail", 80,
+		"largest variable span in lines at or above this triggers a failure (red zone)")
+	Analyzer.Flags.IntVar(&avgWarnAt, "avg-warn", 15,
+		"average variable span in lines at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&avgFailAt, "avg-fail", 25,
+		"average variable span in lines at or above this triggers a failure (red zone)")
+	Analyzer.Flags.IntVar(&stmtsWarnAt, "stmts-warn", 25,
+		"largest variable span in statements at or above this triggers a warning (yellow zone)")
+	Analyzer.Flags.IntVar(&stmtsFailAt, "stmts-fail", 50,
+		"largest variable span in statements at or above this triggers a failure (red zone)")
+	Analyzer.Flags.StringVar(&common.ExcludePatterns, "exclude", "",
+		"comma-separated filename glob patterns to skip (e.g. *_gen.go)")
+}
+
+// span is the distance from a variable's declaration to its last use.
+type span struct {
+	name  string
+	line  int // declaration line
+	lines int
+	stmts int
+}
+
+// spans summarizes the variable spans of one function.
+type spans struct {
+	maxLines span // variable with the largest line span
+	maxStmts span // variable with the largest statement span
+	avgLines float64
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	defaults := common.Thresholds{WarnAt: warnAt, FailAt: failAt}
+	if err := defaults.Validate("varspan"); err != nil {
+		return nil, err
+	}
+	avgDefaults := common.Thresholds{WarnAt: avgWarnAt, FailAt: avgFailAt}
+	if err := avgDefaults.Validate("varspan-avg"); err != nil {
+		return nil, err
+	}
+	stmtsDefaults := common.Thresholds{WarnAt: stmtsWarnAt, FailAt: stmtsFailAt}
+	if err := stmtsDefaults.Validate("varspan-stmts"); err != nil {
+		return nil, err
+	}
+
+	lastUse := lastUses(pass)
+	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		funcDecl := n.(*ast.FuncDecl)
+		if funcDecl.Body == nil {
+			return
+		}
+		if common.IsExcluded(pass.Fset.Position(funcDecl.Pos()).Filename) {
+			return
+		}
+
+		s, ok := measure(pass, funcDecl.Body, lastUse)
+		if !ok {
+			return
+		}
+		funcName := common.FuncName(funcDecl)
+
+		report(pass, funcDecl, common.ParseOverrides(funcDecl, "varspan", defaults), s.maxLines.lines,
+			fmt.Sprintf("function %s has a largest variable span of %d lines (%s, declared on line %d)",
+				funcName, s.maxLines.lines, s.maxLines.name, s.maxLines.line))
+		// Flooring keeps "at or above" exact for integer thresholds.
+		report(pass, funcDecl, common.ParseOverrides(funcDecl, "varspan-avg", avgDefaults), int(s.avgLines),
+			fmt.Sprintf("function %s has an average variable span of %.1f lines", funcName, s.avgLines))
+		report(pass, funcDecl, common.ParseOverrides(funcDecl, "varspan-stmts", stmtsDefaults), s.maxStmts.stmts,
+			fmt.Sprintf("function %s has a largest variable span of %d statements (%s, declared on line %d)",
+				funcName, s.maxStmts.stmts, s.maxStmts.name, s.maxStmts.line))
+	})
+
+	return nil, nil
+}
+
+func report(pass *analysis.Pass, funcDecl *ast.FuncDecl, thresholds common.Thresholds, value int, subject string) {
+	zone := thresholds.Classify(value)
+	if zone == common.ZoneGreen {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:      funcDecl.Pos(),
+		Category: zone.Category(),
+		Message: fmt.Sprintf(
+			"%s (warn: >=%d, fail: >=%d) [%s] "+
+				"(reduce by declaring variables just before their first use, or extracting the code between declaration and use into functions)",
+			subject, thresholds.WarnAt, thresholds.FailAt, zone.Category()),
+	})
+}
+
+// lastUses returns the position of the last reference to each variable in
+// the package.
+func lastUses(pass *analysis.Pass) map[*types.Var]token.Pos {
+	last := make(map[*types.Var]token.Pos)
+	for id, obj := range pass.TypesInfo.Uses {
+		if v, ok := obj.(*types.Var); ok && id.Pos() > last[v] {
+			last[v] = id.Pos()
+		}
+	}
+	return last
+}
+
+// measure computes the spans of the variables declared in body. It reports
+// false when body declares no variables.
+func measure(pass *analysis.Pass, body *ast.BlockStmt, lastUse map[*types.Var]token.Pos) (spans, bool) {
+	stmts := statements(body)
+	var s spans
+	var total, count int
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok || id.Name == "_" {
+			return true
+		}
+		v, ok := pass.TypesInfo.Defs[id].(*types.Var)
+		if !ok || v.IsField() {
+			return true
+		}
+
+		sp := span{name: v.Name(), line: pass.Fset.Position(v.Pos()).Line}
+		if use, used := lastUse[v]; used {
+			sp.lines = pass.Fset.Position(use).Line - sp.line
+			sp.stmts = stmtIndex(stmts, use) - stmtIndex(stmts, v.Pos())
+		}
+		if sp.lines > s.maxLines.lines || count == 0 {
+			s.maxLines = sp
+		}
+		if sp.stmts > s.maxStmts.stmts || count == 0 {
+			s.maxStmts = sp
+		}
+		total += sp.lines
+		count++
+		return true
+	})
+
+	if count == 0 {
+		return s, false
+	}
+	s.avgLines = float64(total) / float64(count)
+	return s, true
+}
+
+// statements returns the statements of body in source order. Blocks are
+// not statements of their own.
+func statements(body *ast.BlockStmt) []ast.Stmt {
+	var stmts []ast.Stmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		if stmt, ok := n.(ast.Stmt); ok {
+			if _, isBlock := stmt.(*ast.BlockStmt); !isBlock {
+				stmts = append(stmts, stmt)
+			}
+		}
+		return true
+	})
+	return stmts
+}
+
+// stmtIndex returns the index of the innermost statement containing pos.
+func stmtIndex(stmts []ast.Stmt, pos token.Pos) int {
+	index := 0
+	for i, stmt := range stmts {
+		if stmt.Pos() <= pos && pos < stmt.End() {
+			index = i
+		}
+	}
+	return index
+}
@@ -0,0 +1,21 @@
+package varspan_test
+
+import (
+	"testing"
+
+	"github.com/glemzurg/go-complexity-lint/internal/testutil"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/varspan"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestVarSpan(t *testing.T) {
+	testutil.SetFlag(t, varspan.Analyzer, "warn", "7")
+	testutil.SetFlag(t, varspan.Analyzer, "fail", "10")
+	testutil.SetFlag(t, varspan.Analyzer, "avg-warn", "5")
+	testutil.SetFlag(t, varspan.Analyzer, "avg-fail", "8")
+	testutil.SetFlag(t, varspan.Analyzer, "stmts-warn", "5")
+	testutil.SetFlag(t, varspan.Analyzer, "stmts-fail", "10")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, varspan.Analyzer, "varspan")
+}
@@ -2,11 +2,28 @@ package plugin
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/apisurface"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/chainlen"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/clones"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/coupling"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/cyclo"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/embeddepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/errpaths"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/fanout"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/filesize"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/generics"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/importdepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/mutation"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/nestdepth"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/outliers"
 	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/params"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/risk"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/sideeffects"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/typeexpr"
+	"github.com/glemzurg/go-complexity-lint/pkg/analyzer/varspan"
 	"github.com/golangci/plugin-module-register/register"
 	"golang.org/x/tools/go/analysis"
 )
@@ -16,15 +33,86 @@ func init() {
 }
 
 type Settings struct {
-	NestdepthWarn *int    `json:"nestdepth-warn"`
-	NestdepthFail *int    `json:"nestdepth-fail"`
-	CycloWarn     *int    `json:"cyclo-warn"`
-	CycloFail     *int    `json:"cyclo-fail"`
-	ParamsWarn    *int    `json:"params-warn"`
-	ParamsFail    *int    `json:"params-fail"`
-	FanoutWarn    *int    `json:"fanout-warn"`
-	FanoutFail    *int    `json:"fanout-fail"`
-	Exclude       *string `json:"exclude"`
+	NestdepthWarn             *int    `json:"nestdepth-warn"`
+	NestdepthFail             *int    `json:"nestdepth-fail"`
+	CycloWarn                 *int    `json:"cyclo-warn"`
+	CycloFail                 *int    `json:"cyclo-fail"`
+	ParamsWarn                *int    `json:"params-warn"`
+	ParamsFail                *int    `json:"params-fail"`
+	ParamsCtxNames            *string `json:"params-ctx-names"`
+	ParamsExempt              *string `json:"params-exempt"`
+	ParamsWeighted            *bool   `json:"params-weighted"`
+	FanoutWarn                *int    `json:"fanout-warn"`
+	FanoutFail                *int    `json:"fanout-fail"`
+	TypeexprWarn              *int    `json:"typeexpr-warn"`
+	TypeexprFail              *int    `json:"typeexpr-fail"`
+	TypeexprSizeWarn          *int    `json:"typeexpr-size-warn"`
+	TypeexprSizeFail          *int    `json:"typeexpr-size-fail"`
+	GenericsWarn              *int    `json:"generics-warn"`
+	GenericsFail              *int    `json:"generics-fail"`
+	GenericsConstraintWarn    *int    `json:"generics-constraint-warn"`
+	GenericsConstraintFail    *int    `json:"generics-constraint-fail"`
+	EmbeddepthWarn            *int    `json:"embeddepth-warn"`
+	EmbeddepthFail            *int    `json:"embeddepth-fail"`
+	EmbeddepthAmbiguityWarn   *int    `json:"embeddepth-ambiguity-warn"`
+	EmbeddepthAmbiguityFail   *int    `json:"embeddepth-ambiguity-fail"`
+	CouplingEfferentWarn      *int    `json:"coupling-efferent-warn"`
+	CouplingEfferentFail      *int    `json:"coupling-efferent-fail"`
+	ImportdepthWarn           *int    `json:"importdepth-warn"`
+	ImportdepthFail           *int    `json:"importdepth-fail"`
+	ImportdepthTransitiveWarn *int    `json:"importdepth-transitive-warn"`
+	ImportdepthTransitiveFail *int    `json:"importdepth-transitive-fail"`
+	ApisurfaceWarn            *int    `json:"apisurface-warn"`
+	ApisurfaceFail            *int    `json:"apisurface-fail"`
+	ApisurfaceParamsWarn      *int    `json:"apisurface-params-warn"`
+	ApisurfaceParamsFail      *int    `json:"apisurface-params-fail"`
+	FilesizeWarn              *int    `json:"filesize-warn"`
+	FilesizeFail              *int    `json:"filesize-fail"`
+	FilesizeDeclsWarn         *int    `json:"filesize-decls-warn"`
+	FilesizeDeclsFail         *int    `json:"filesize-decls-fail"`
+	FilesizeFuncsWarn         *int    `json:"filesize-funcs-warn"`
+	FilesizeFuncsFail         *int    `json:"filesize-funcs-fail"`
+	ChainlenWarn              *int    `json:"chainlen-warn"`
+	ChainlenFail              *int    `json:"chainlen-fail"`
+	ChainlenExempt            *string `json:"chainlen-exempt"`
+	MutationWarn              *int    `json:"mutation-warn"`
+	MutationFail              *int    `json:"mutation-fail"`
+	VarspanWarn               *int    `json:"varspan-warn"`
+	VarspanFail               *int    `json:"varspan-fail"`
+	VarspanAvgWarn            *int    `json:"varspan-avg-warn"`
+	VarspanAvgFail            *int    `json:"varspan-avg-fail"`
+	VarspanStmtsWarn          *int    `json:"varspan-stmts-warn"`
+	VarspanStmtsFail          *int    `json:"varspan-stmts-fail"`
+	ErrpathsWarn              *int    `json:"errpaths-warn"`
+	ErrpathsFail              *int    `json:"errpaths-fail"`
+	SideeffectsWarn           *int    `json:"sideeffects-warn"`
+	SideeffectsFail           *int    `json:"sideeffects-fail"`
+	SideeffectsPackages       *string `json:"sideeffects-packages"`
+	SideeffectsExtraPackages  *string `json:"sideeffects-extra-packages"`
+	ClonesWarn                *int    `json:"clones-warn"`
+	ClonesFail                *int    `json:"clones-fail"`
+	RiskWarn                  *int    `json:"risk-warn"`
+	RiskFail                  *int    `json:"risk-fail"`
+	RiskNestdepthWeight       *int    `json:"risk-nestdepth-weight"`
+	RiskCycloWeight           *int    `json:"risk-cyclo-weight"`
+	RiskParamsWeight          *int    `json:"risk-params-weight"`
+	RiskFanoutWeight          *int    `json:"risk-fanout-weight"`
+	OutliersEnable            *bool   `json:"outliers-enable"`
+	OutliersErrors            *bool   `json:"outliers-errors"`
+	OutliersWarn              *int    `json:"outliers-warn"`
+	OutliersFail              *int    `json:"outliers-fail"`
+	OutliersSigmaWarn         *int    `json:"outliers-sigma-warn"`
+	OutliersSigmaFail         *int    `json:"outliers-sigma-fail"`
+	OutliersMode              *string `json:"outliers-mode"`
+	OutliersMinFuncs          *int    `json:"outliers-min-funcs"`
+	OutliersFloor             *int    `json:"outliers-floor"`
+	OutliersScope             *string `json:"outliers-scope"`
+	OutliersReplace           *bool   `json:"outliers-replace"`
+	FuncLits                  *bool   `json:"funclits"`
+	FuncLitsDetach            *bool   `json:"funclits-detach"`
+	Iterators                 *bool   `json:"iterators"`
+	Guards                    *string `json:"guards"`
+	Exclude                   *string `json:"exclude"`
 }
 
 func New(conf any) (register.LinterPlugin, error) {
@@ -45,32 +133,113 @@ func (p *complexityPlugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
 		cyclo.Analyzer,
 		params.Analyzer,
 		fanout.Analyzer,
+		typeexpr.Analyzer,
+		generics.Analyzer,
+		embeddepth.Analyzer,
+		coupling.Analyzer,
+		importdepth.Analyzer,
+		apisurface.Analyzer,
+		filesize.Analyzer,
+		chainlen.Analyzer,
+		mutation.Analyzer,
+		varspan.Analyzer,
+		errpaths.Analyzer,
+		sideeffects.Analyzer,
+		clones.Analyzer,
+		risk.Analyzer,
+		outliers.Analyzer,
 	}
 
+	// prefix selects an analyzer's secondary threshold pair ("size-" sets
+	// size-warn and size-fail); empty means the primary warn/fail pair.
 	flagOverrides := []struct {
 		analyzer *analysis.Analyzer
+		prefix   string
 		warn     *int
 		fail     *int
 	}{
-		{nestdepth.Analyzer, p.settings.NestdepthWarn, p.settings.NestdepthFail},
-		{cyclo.Analyzer, p.settings.CycloWarn, p.settings.CycloFail},
-		{params.Analyzer, p.settings.ParamsWarn, p.settings.ParamsFail},
-		{fanout.Analyzer, p.settings.FanoutWarn, p.settings.FanoutFail},
+		{nestdepth.Analyzer, "", p.settings.NestdepthWarn, p.settings.NestdepthFail},
+		{cyclo.Analyzer, "", p.settings.CycloWarn, p.settings.CycloFail},
+		{params.Analyzer, "", p.settings.ParamsWarn, p.settings.ParamsFail},
+		{fanout.Analyzer, "", p.settings.FanoutWarn, p.settings.FanoutFail},
+		{typeexpr.Analyzer, "", p.settings.TypeexprWarn, p.settings.TypeexprFail},
+		{typeexpr.Analyzer, "size-", p.settings.TypeexprSizeWarn, p.settings.TypeexprSizeFail},
+		{generics.Analyzer, "", p.settings.GenericsWarn, p.settings.GenericsFail},
+		{generics.Analyzer, "constraint-", p.settings.GenericsConstraintWarn, p.settings.GenericsConstraintFail},
+		{embeddepth.Analyzer, "", p.settings.EmbeddepthWarn, p.settings.EmbeddepthFail},
+		{embeddepth.Analyzer, "ambiguity-", p.settings.EmbeddepthAmbiguityWarn, p.settings.EmbeddepthAmbiguityFail},
+		// Only efferent coupling is reported under golangci-lint; the
+		// distance metric needs the whole import graph.
+		{coupling.Analyzer, "efferent-", p.settings.CouplingEfferentWarn, p.settings.CouplingEfferentFail},
+		{importdepth.Analyzer, "", p.settings.ImportdepthWarn, p.settings.ImportdepthFail},
+		{importdepth.Analyzer, "transitive-", p.settings.ImportdepthTransitiveWarn, p.settings.ImportdepthTransitiveFail},
+		{apisurface.Analyzer, "", p.settings.ApisurfaceWarn, p.settings.ApisurfaceFail},
+		{apisurface.Analyzer, "params-", p.settings.ApisurfaceParamsWarn, p.settings.ApisurfaceParamsFail},
+		{filesize.Analyzer, "", p.settings.FilesizeWarn, p.settings.FilesizeFail},
+		{filesize.Analyzer, "decls-", p.settings.FilesizeDeclsWarn, p.settings.FilesizeDeclsFail},
+		{filesize.Analyzer, "funcs-", p.settings.FilesizeFuncsWarn, p.settings.FilesizeFuncsFail},
+		{chainlen.Analyzer, "", p.settings.ChainlenWarn, p.settings.ChainlenFail},
+		{mutation.Analyzer, "", p.settings.MutationWarn, p.settings.MutationFail},
+		{varspan.Analyzer, "", p.settings.VarspanWarn, p.settings.VarspanFail},
+		{varspan.Analyzer, "avg-", p.settings.VarspanAvgWarn, p.settings.VarspanAvgFail},
+		{varspan.Analyzer, "stmts-", p.settings.VarspanStmtsWarn, p.settings.VarspanStmtsFail},
+		{errpaths.Analyzer, "", p.settings.ErrpathsWarn, p.settings.ErrpathsFail},
+		{sideeffects.Analyzer, "", p.settings.SideeffectsWarn, p.settings.SideeffectsFail},
+		{clones.Analyzer, "", p.settings.ClonesWarn, p.settings.ClonesFail},
+		{risk.Analyzer, "", p.settings.RiskWarn, p.settings.RiskFail},
+		{outliers.Analyzer, "", p.settings.OutliersWarn, p.settings.OutliersFail},
+		{outliers.Analyzer, "sigma-", p.settings.OutliersSigmaWarn, p.settings.OutliersSigmaFail},
 	}
 
 	for _, o := range flagOverrides {
 		if o.warn != nil {
-			if err := o.analyzer.Flags.Set("warn", fmt.Sprint(*o.warn)); err != nil {
-				return nil, fmt.Errorf("setting %s.warn: %w", o.analyzer.Name, err)
+			if err := o.analyzer.Flags.Set(o.prefix+"warn", fmt.Sprint(*o.warn)); err != nil {
+				return nil, fmt.Errorf("setting %s.%swarn: %w", o.analyzer.Name, o.prefix, err)
 			}
 		}
 		if o.fail != nil {
-			if err := o.analyzer.Flags.Set("fail", fmt.Sprint(*o.fail)); err != nil {
-				return nil, fmt.Errorf("setting %s.fail: %w", o.analyzer.Name, err)
+			if err := o.analyzer.Flags.Set(o.prefix+"fail", fmt.Sprint(*o.fail)); err != nil {
+				return nil, fmt.Errorf("setting %s.%sfail: %w", o.analyzer.Name, o.prefix, err)
 			}
 		}
 	}
 
+	// Options other than thresholds; nil values keep the analyzer default.
+	options := []struct {
+		analyzer *analysis.Analyzer
+		name     string
+		value    *string
+	}{
+		{params.Analyzer, "ctx-names", p.settings.ParamsCtxNames},
+		{params.Analyzer, "exempt", p.settings.ParamsExempt},
+		{params.Analyzer, "weighted", boolOption(p.settings.ParamsWeighted)},
+		{chainlen.Analyzer, "exempt", p.settings.ChainlenExempt},
+		{sideeffects.Analyzer, "packages", p.settings.SideeffectsPackages},
+		{sideeffects.Analyzer, "extra-packages", p.settings.SideeffectsExtraPackages},
+		{risk.Analyzer, "nestdepth-weight", intOption(p.settings.RiskNestdepthWeight)},
+		{risk.Analyzer, "cyclo-weight", intOption(p.settings.RiskCycloWeight)},
+		{risk.Analyzer, "params-weight", intOption(p.settings.RiskParamsWeight)},
+		{risk.Analyzer, "fanout-weight", intOption(p.settings.RiskFanoutWeight)},
+		{outliers.Analyzer, "enable", boolOption(p.settings.OutliersEnable)},
+		{outliers.Analyzer, "errors", boolOption(p.settings.OutliersErrors)},
+		{outliers.Analyzer, "mode", p.settings.OutliersMode},
+		{outliers.Analyzer, "min-funcs", intOption(p.settings.OutliersMinFuncs)},
+		{outliers.Analyzer, "floor", intOption(p.settings.OutliersFloor)},
+		// golangci-lint sees one package at a time, so module scope falls
+		// back to package scope.
+		{outliers.Analyzer, "scope", p.settings.OutliersScope},
+		// Shared by nestdepth, cyclo, fanout and errpaths.
+		{cyclo.Analyzer, "guards", p.settings.Guards},
+	}
+	for _, o := range options {
+		if o.value == nil {
+			continue
+		}
+		if err := o.analyzer.Flags.Set(o.name, *o.value); err != nil {
+			return nil, fmt.Errorf("setting %s.%s: %w", o.analyzer.Name, o.name, err)
+		}
+	}
+
 	if p.settings.Exclude != nil {
 		// All analyzers share the same exclude variable; setting it on one is sufficient.
 		if err := cyclo.Analyzer.Flags.Set("exclude", *p.settings.Exclude); err != nil {
@@ -78,9 +247,62 @@ func (p *complexityPlugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
 		}
 	}
 
+	// Like exclude, the function literal and iterator options are shared by
+	// the per-function analyzers, nestdepth among them; setting them on one is
+	// sufficient.
+	shared := []struct {
+		name  string
+		value *bool
+	}{
+		{"funclits", p.settings.FuncLits},
+		{"funclits-detach", p.settings.FuncLitsDetach},
+		{"iterators", p.settings.Iterators},
+	}
+	for _, o := range shared {
+		if o.value == nil {
+			continue
+		}
+		if err := nestdepth.Analyzer.Flags.Set(o.name, strconv.FormatBool(*o.value)); err != nil {
+			return nil, fmt.Errorf("setting %s: %w", o.name, err)
+		}
+	}
+
+	if replaceMetrics(p.settings) {
+		// golangci-lint only reports the analyzers it is given; the metric
+		// analyzers still run as requirements of outliers.
+		analyzers = slices.DeleteFunc(analyzers, func(a *analysis.Analyzer) bool {
+			return slices.Contains(outliers.Analyzer.Requires, a)
+		})
+	}
+
 	return analyzers, nil
 }
 
+// replaceMetrics reports whether outliers replace the absolute nestdepth,
+// cyclo, params and fanout diagnostics.
+func replaceMetrics(s Settings) bool {
+	return s.OutliersEnable != nil && *s.OutliersEnable &&
+		s.OutliersReplace != nil && *s.OutliersReplace
+}
+
 func (p *complexityPlugin) GetLoadMode() string {
 	return register.LoadModeTypesInfo
 }
+
+// intOption formats an optional integer setting as a flag value.
+func intOption(v *int) *string {
+	if v == nil {
+		return nil
+	}
+	s := strconv.Itoa(*v)
+	return &s
+}
+
+// boolOption formats an optional boolean setting as a flag value.
+func boolOption(v *bool) *string {
+	if v == nil {
+		return nil
+	}
+	s := strconv.FormatBool(*v)
+	return &s
+}